@@ -106,6 +106,12 @@ func run(opts *Options) {
 		"    --query \"Items.Fields.Status.Name == \\\"In progress\\\"\" \\\n" +
 		"    --iteration-current\n" +
 		"\n" +
+		"# List the bugs of the current sprint not updated for a week.\n" +
+		"$ gh iteration items-list \\\n" +
+		"    --owner <OWNER> \\\n" +
+		"    --project <PROJECT_NUM> \\\n" +
+		"    --query \"inIteration(\\\"<FIELD_NAME>\\\", \\\"current\\\") && hasLabel(\\\"bug\\\") && daysSince(Item.UpdatedAt) >= 7\"\n" +
+		"\n" +
 		"```\n" +
 		"\n"
 
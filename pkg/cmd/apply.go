@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type ApplyProps struct {
+	OutputFormatJSON *bool
+	NewClient        ClientFactory
+}
+
+type ApplyOption struct {
+	Plan      string
+	Journal   string
+	BatchSize int
+}
+
+func NewApplyCmd(props *ApplyProps) *cobra.Command {
+	opts := new(ApplyOption)
+
+	// applyCmd represents the apply command.
+	applyCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "apply",
+		Short: "Apply the changes planned by items-edit",
+		Long: `Apply the changes planned by items-edit --plan-out.
+Nothing is applied if the iteration of any planned item has changed since planning.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			validator := flags.NewValidator(
+				flags.And(
+					flags.Flag("plan"),
+				),
+			)
+			err := validator.Validate(cmd)
+			if err != nil {
+				return fmt.Errorf("flags: %w", err)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			applyRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	applyCmd.Flags().SortFlags = false
+	applyCmd.Flags().StringVar(&opts.Plan, "plan", "", "Plan file written by items-edit")
+	applyCmd.Flags().StringVar(&opts.Journal, "journal", "",
+		"File to append the journal of the updated items to, for undo (default: a new file of the run in "+journalDirHelp+")")
+	applyCmd.Flags().IntVar(&opts.BatchSize, "batch-size", 1, "Number of project items to update in a request (up to 100)")
+	_ = applyCmd.MarkFlagRequired("plan")
+
+	return applyCmd
+}
+
+//nolint:funlen,cyclop
+func applyRun(out io.Writer, props *ApplyProps, opts *ApplyOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	if opts.BatchSize < 1 || opts.BatchSize > github.MaxBatchSize {
+		log.Error(fmt.Errorf("batch size must be between 1 and %d: %d", github.MaxBatchSize, opts.BatchSize))
+		os.Exit(1)
+	}
+
+	plan, err := readPlan(opts.Plan)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	log.Debug("Retrieve an iteration field by field ID")
+	iterationField, err := client.FetchIterationFieldByID(plan.FieldID)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve an iteration field by field ID: %w", err))
+		os.Exit(1)
+	}
+
+	log.Debug("Retrieve project items")
+	githubItems, err := client.FetchProjectItems(plan.ProjectID, github.DefaultItemsPageSize, 0)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve project items: %w", err))
+		os.Exit(1)
+	}
+	items := map[string]ProjectItem{}
+	for _, githubItem := range *githubItems {
+		item := ConvertGitHubProjectItem(&githubItem)
+		items[item.ID] = item
+	}
+
+	err = checkPlanDrift(plan, items, iterationField.Name)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	journal, err := openJournal(opts.Journal)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	// failed is set by the first failure. The rest of its batch is still reported and journaled,
+	// so that the journal has every update made.
+	failed := false
+	for start := 0; start < len(plan.Changes) && !failed; start += opts.BatchSize {
+		changes := plan.Changes[start:min(start+opts.BatchSize, len(plan.Changes))]
+		updates := make([]github.IterationFieldUpdate, 0, len(changes))
+		for _, change := range changes {
+			updates = append(updates, github.IterationFieldUpdate{ItemID: change.ItemID, IterationID: change.ToIterationID})
+		}
+
+		errs, batchErr := client.UpdateIterationFields(plan.ProjectID, plan.FieldID, updates, len(updates))
+		for i, change := range changes {
+			err := batchErr
+			if err == nil {
+				err = errs[i]
+			}
+			errMessage := ""
+			if err != nil {
+				log.Error(fmt.Errorf("failed to update an iteration field of %s: %w", change.ItemID, err))
+				errMessage = err.Error()
+				failed = true
+			} else {
+				err := journal.write(newJournalEntry(plan.ProjectID, plan.FieldID, change))
+				if err != nil {
+					log.Error(err)
+					failed = true
+				}
+			}
+			item := items[change.ItemID]
+			newIterationTitle := ""
+			if newIteration, err := findIterationByID(iterationField, change.ToIterationID); err == nil {
+				newIterationTitle = newIteration.Title
+			}
+			printItemsEditResult(out, *props.OutputFormatJSON, itemsEditResult{
+				ID:                     item.ID,
+				Title:                  item.Title,
+				PreviousIterationID:    change.FromIterationID,
+				PreviousIterationTitle: itemIteration(item, iterationField.Name).Title,
+				NewIterationID:         change.ToIterationID,
+				NewIterationTitle:      newIterationTitle,
+				Skipped:                false,
+				DryRun:                 false,
+				Error:                  errMessage,
+				err:                    err,
+				change:                 nil,
+			})
+		}
+	}
+
+	err = journal.Close()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	if failed {
+		os.Exit(1)
+	}
+
+	if !*props.OutputFormatJSON {
+		_, _ = fmt.Fprintf(out, "%d changes applied.\n", len(plan.Changes))
+	}
+}
+
+// checkPlanDrift returns an error listing the planned items whose iteration is no longer the planned one.
+func checkPlanDrift(plan *itemsEditPlan, items map[string]ProjectItem, fieldName string) error {
+	var drifts []string
+	for _, change := range plan.Changes {
+		item, ok := items[change.ItemID]
+		if !ok {
+			drifts = append(drifts, change.ItemID+" (not found)")
+			continue
+		}
+		iterationID := itemIteration(item, fieldName).IterationID
+		if iterationID != change.FromIterationID {
+			drifts = append(drifts, fmt.Sprintf("%s (planned from %q, now %q)", change.ItemID, change.FromIterationID, iterationID))
+		}
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("the project has changed since planning, nothing is applied: %s", strings.Join(drifts, ", "))
+	}
+	return nil
+}
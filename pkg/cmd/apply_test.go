@@ -0,0 +1,85 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	plan := filepath.Join(t.TempDir(), "plan.json")
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--current",
+		"--date", fixtureToday, "--plan-out", plan)
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> Sprint 3) => DryRun.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> Sprint 3) => No need to update. Skipped.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> Sprint 3) => DryRun.\n" +
+		"6 items scanned.\n" +
+		"2 changes planned in " + plan + ".\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_2")
+
+	got = runCmd(t, server, "apply", "--plan", plan)
+	want = "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> Sprint 3) => Updated.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> Sprint 3) => Updated.\n" +
+		"2 changes applied.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_6", "Sprint", "sprint_3")
+}
+
+func TestApplyDrift(t *testing.T) {
+	t.Parallel()
+
+	plan := filepath.Join(t.TempDir(), "plan.json")
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) {
+		runCmd(t, server,
+			"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--clear",
+			"--plan-out", plan)
+		runCmd(t, server,
+			"item-edit", "--id", "PVTI_1", "--field", "Sprint", "--iteration", "Sprint 4")
+	}, "apply", "--plan", plan)
+	assertOutput(t, "", got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+}
+
+func TestApplyFailure(t *testing.T) {
+	t.Parallel()
+
+	// The batch updates PVTI_3 along with the failed PVTI_1.
+	plan := filepath.Join(t.TempDir(), "plan.json")
+	journal := subprocessTempFile(t, "journal.jsonl")
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) {
+		runCmd(t, server,
+			"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--clear",
+			"--plan-out", plan)
+		server.FailItem("PVTI_1")
+	}, "apply", "--plan", plan, "--batch-size", "2", "--journal", journal)
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Failed. failed to update the iteration field: " +
+		"GraphQL: something went wrong while updating the item 'PVTI_1' (m0)\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n"
+	assertOutput(t, want, got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+
+	bytes, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bytes), `"itemId":"PVTI_3"`) || strings.Contains(string(bytes), `"itemId":"PVTI_1"`) {
+		t.Errorf("wrong journal want: PVTI_3 only, got %s", bytes)
+	}
+}
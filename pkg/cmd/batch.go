@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// BatchOption holds the flags to send the updates of project items in batches.
+type BatchOption struct {
+	Concurrency     int
+	BatchSize       int
+	ContinueOnError bool
+	Journal         string
+}
+
+func addBatchFlags(cmd *cobra.Command, opts *BatchOption) {
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 1, "Number of project items to update concurrently")
+	cmd.Flags().IntVar(&opts.BatchSize, "batch-size", 1, "Number of project items to update in a request (up to 100)")
+	cmd.Flags().BoolVar(&opts.ContinueOnError, "continue-on-error", false,
+		"Continue updating the other items when an item fails, and exit with status 2 at the end")
+	cmd.Flags().StringVar(&opts.Journal, "journal", "",
+		"File to append the journal of the updated items to, for undo (default: a new file of the run in "+journalDirHelp+")")
+}
+
+func (o *BatchOption) validate() error {
+	if o.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be positive: %d", o.Concurrency)
+	}
+	if o.BatchSize < 1 || o.BatchSize > github.MaxBatchSize {
+		return fmt.Errorf("batch size must be between 1 and %d: %d", github.MaxBatchSize, o.BatchSize)
+	}
+	return nil
+}
+
+// itemPlan is the result of editing a project item planned before sending, with the update to send, or nil if nothing is sent.
+// The result records the change of the item unless it is skipped.
+type itemPlan struct {
+	result itemsEditResult
+	update *github.IterationFieldUpdate
+}
+
+// editItems sends the planned updates in batches, and reports the result of each item in the order of the plans.
+// The updates made are appended to the journal unless it is nil.
+// It returns the changes made, the summary of the results and whether it stopped at a failure without ContinueOnError.
+// The batches already sent are still reported and journaled when it stops, so that the journal has every update made.
+func editItems(
+	client *github.Client, projectID string, fieldID string, plans []itemPlan,
+	opts *BatchOption, journal *journalWriter, report func(result itemsEditResult),
+) ([]itemsEditChange, itemsEditSummary, bool) {
+	// editBatch edits the items in a batch, sending their updates in a request.
+	editBatch := func(batch []itemPlan) []itemsEditResult {
+		results := make([]itemsEditResult, 0, len(batch))
+		var updates []github.IterationFieldUpdate
+		var updated []int
+		for _, plan := range batch {
+			if plan.update != nil {
+				updates = append(updates, *plan.update)
+				updated = append(updated, len(results))
+			}
+			results = append(results, plan.result)
+		}
+		if len(updates) == 0 {
+			return results
+		}
+
+		errs, err := client.UpdateIterationFields(projectID, fieldID, updates, len(updates))
+		for i, index := range updated {
+			if err != nil {
+				results[index].err = err
+			} else {
+				results[index].err = errs[i]
+			}
+		}
+		return results
+	}
+
+	changes := []itemsEditChange{}
+	var summary itemsEditSummary
+	batchCount := (len(plans) + opts.BatchSize - 1) / opts.BatchSize
+	stopped := false
+	forEachOrdered(batchCount, opts.Concurrency, func(i int) []itemsEditResult {
+		return editBatch(plans[i*opts.BatchSize : min((i+1)*opts.BatchSize, len(plans))])
+	}, func(_ int, results []itemsEditResult) bool {
+		for _, result := range results {
+			if result.err != nil {
+				log.Error(fmt.Errorf("failed to update an iteration field of %s: %w", result.ID, result.err))
+				result.Error = result.err.Error()
+				stopped = stopped || !opts.ContinueOnError
+			} else if result.change != nil {
+				changes = append(changes, *result.change)
+				if journal != nil {
+					err := journal.write(newJournalEntry(projectID, fieldID, *result.change))
+					if err != nil {
+						log.Error(err)
+						stopped = true
+					}
+				}
+			}
+			summary.add(result)
+			report(result)
+		}
+		return !stopped
+	})
+	return changes, summary, stopped
+}
@@ -0,0 +1,142 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/cmd"
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+// fixtureToday is the date the default fixture is built around.
+const fixtureToday = "2026-10-18"
+
+// TestMain keeps the config directory of the user out of the tests, as items-edit writes its journal there by default.
+// The subprocesses of runCmdInSubprocess share the directory of their test.
+func TestMain(m *testing.M) {
+	if os.Getenv("GH_ITERATION_TEST_SUBPROCESS") == "1" {
+		os.Exit(m.Run())
+	}
+	dir, err := os.MkdirTemp("", "gh-iteration-test")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_CONFIG_HOME", dir)
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newServer starts a fake server serving the default fixture.
+func newServer(t *testing.T) *githubtest.Server {
+	t.Helper()
+
+	server := githubtest.NewServer(githubtest.DefaultFixture())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runCmd runs the root command with the arguments against the server and returns the standard output.
+func runCmd(t *testing.T, server *githubtest.Server, args ...string) string {
+	t.Helper()
+
+	root := cmd.NewRootCmdWithClientFactory(server.NewClient)
+	var stdout, stderr bytes.Buffer
+	root.SetIn(&bytes.Buffer{})
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(args)
+
+	err := root.Execute()
+	if err != nil {
+		t.Fatalf("failed to run %v: %v\n%s", args, err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func assertOutput(t *testing.T, want string, got string) {
+	t.Helper()
+
+	if got != want {
+		t.Errorf("wrong output\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func assertFieldValue(t *testing.T, server *githubtest.Server, itemID string, fieldName string, want any) {
+	t.Helper()
+
+	if got := server.FieldValue(itemID, fieldName); got != want {
+		t.Errorf("wrong %s of %s want: %v, got %v", fieldName, itemID, want, got)
+	}
+}
+
+func assertNoOperation(t *testing.T, server *githubtest.Server, name string) {
+	t.Helper()
+
+	if slices.Contains(server.Operations(), name) {
+		t.Errorf("want no %s, got %v", name, server.Operations())
+	}
+}
+
+// runCmdInSubprocess runs the root command like runCmd in a subprocess running the calling test,
+// so that a command calling os.Exit can be tested. It returns the standard output and the exit code.
+// The subprocess runs the calling test up to the call, so the test must not check anything before it.
+func runCmdInSubprocess(t *testing.T, prepare func(*githubtest.Server), args ...string) (string, int) {
+	t.Helper()
+
+	if os.Getenv("GH_ITERATION_TEST_SUBPROCESS") == "1" {
+		server := githubtest.NewServer(githubtest.DefaultFixture())
+		prepare(server)
+		root := cmd.NewRootCmdWithClientFactory(server.NewClient)
+		root.SetOut(os.Stdout)
+		root.SetArgs(args)
+		err := root.Execute()
+		server.Close()
+		if err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	//nolint:gosec
+	subprocess := exec.CommandContext(t.Context(), os.Args[0], "-test.run=^"+t.Name()+"$")
+	subprocess.Env = append(os.Environ(), "GH_ITERATION_TEST_SUBPROCESS=1")
+	if dir, ok := subprocessTempDirs.Load(t); ok {
+		subprocess.Env = append(subprocess.Env, "GH_ITERATION_TEST_TEMP_DIR="+dir.(string)) //nolint:forcetypeassert
+	}
+	var stdout bytes.Buffer
+	subprocess.Stdout = &stdout
+	err := subprocess.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("failed to run %v: %v", args, err)
+	}
+	if strings.Contains(stdout.String(), "--- FAIL") {
+		t.Fatalf("failed to prepare %v in the subprocess:\n%s", args, stdout.String())
+	}
+	return stdout.String(), subprocess.ProcessState.ExitCode()
+}
+
+// subprocessTempDirs maps a test to its temporary directory shared with its subprocess.
+//
+//nolint:gochecknoglobals
+var subprocessTempDirs sync.Map
+
+// subprocessTempFile returns the path of a file in a temporary directory shared by the test and the subprocess
+// of runCmdInSubprocess, so that the test can check the files written by the command.
+func subprocessTempFile(t *testing.T, name string) string {
+	t.Helper()
+
+	if os.Getenv("GH_ITERATION_TEST_SUBPROCESS") == "1" {
+		return filepath.Join(os.Getenv("GH_ITERATION_TEST_TEMP_DIR"), name)
+	}
+	dir, _ := subprocessTempDirs.LoadOrStore(t, t.TempDir())
+	return filepath.Join(dir.(string), name) //nolint:forcetypeassert
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"sync/atomic"
+)
+
+// forEachOrdered runs job for the indexes 0 to n-1 on up to concurrency goroutines
+// and passes the results to emit in the order of the indexes.
+// Once emit returns false, the jobs not started yet are skipped.
+func forEachOrdered[R any](n int, concurrency int, job func(i int) R, emit func(i int, result R) bool) {
+	results := make([]chan R, n)
+	for i := range results {
+		results[i] = make(chan R, 1)
+	}
+
+	var stopped atomic.Bool
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	go func() {
+		for i := range n {
+			semaphore <- struct{}{}
+			if stopped.Load() {
+				<-semaphore
+				close(results[i])
+				continue
+			}
+			go func() {
+				defer func() { <-semaphore }()
+				results[i] <- job(i)
+			}()
+		}
+	}()
+
+	for i := range n {
+		result, ok := <-results[i]
+		if !ok {
+			continue
+		}
+		if !emit(i, result) {
+			stopped.Store(true)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/config"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type ConfigProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+}
+
+type ConfigSetOption struct {
+	Local bool
+}
+
+const configKeysHelp = `Keys are owner, project, field, queries.<name>,
+and presets.<name>.owner, presets.<name>.project, presets.<name>.field, presets.<name>.query.`
+
+func NewConfigCmd(props *ConfigProps) *cobra.Command {
+	// configCmd represents the config command.
+	configCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "config",
+		Short: "Manage the config file",
+		Long: `Manage the config file of default options, saved queries and presets.
+The config is read from ~/.config/gh-iteration/config.yml and from ` + config.LocalFileName + ` at the root of the repository,
+whose values take precedence, or only from the file given by --config.
+A preset is given by --preset of items-edit and items-list. Its query is a query or the name of a saved query.
+
+` + configKeysHelp,
+		Args: cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(newConfigListCmd(props))
+	configCmd.AddCommand(newConfigGetCmd(props))
+	configCmd.AddCommand(newConfigSetCmd(props))
+
+	return configCmd
+}
+
+func newConfigListCmd(props *ConfigProps) *cobra.Command {
+	// configListCmd represents the config list command.
+	configListCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "list",
+		Short: "List the config values",
+		Long:  `List the config values`,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			configListRun(cmd.OutOrStdout(), props)
+		},
+	}
+	return configListCmd
+}
+
+func newConfigGetCmd(props *ConfigProps) *cobra.Command {
+	// configGetCmd represents the config get command.
+	configGetCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "get <key>",
+		Short: "Get a config value",
+		Long:  "Get a config value.\n" + configKeysHelp,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			configGetRun(cmd.OutOrStdout(), props, args[0])
+		},
+	}
+	return configGetCmd
+}
+
+func newConfigSetCmd(props *ConfigProps) *cobra.Command {
+	opts := new(ConfigSetOption)
+
+	// configSetCmd represents the config set command.
+	configSetCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "set <key> <value>",
+		Short: "Set a config value",
+		Long:  "Set a config value, or unset it with an empty value.\n" + configKeysHelp,
+		Args:  cobra.ExactArgs(2), //nolint:mnd
+		Run: func(cmd *cobra.Command, args []string) {
+			configSetRun(cmd.OutOrStdout(), props, opts, args[0], args[1])
+		},
+	}
+
+	configSetCmd.Flags().SortFlags = false
+	configSetCmd.Flags().BoolVar(&opts.Local, "local", false, "Set the value in "+config.LocalFileName+" of the repository")
+
+	return configSetCmd
+}
+
+func configListRun(out io.Writer, props *ConfigProps) {
+	conf, err := config.Load(*props.ConfigPath)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	entries := conf.Entries()
+	if *props.OutputFormatJSON {
+		if entries == nil {
+			entries = []config.Entry{}
+		}
+		bytes, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal the config: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+	} else {
+		for _, entry := range entries {
+			_, _ = fmt.Fprintf(out, "%s=%s\n", entry.Key, entry.Value)
+		}
+	}
+}
+
+func configGetRun(out io.Writer, props *ConfigProps, key string) {
+	conf, err := config.Load(*props.ConfigPath)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	value, ok := conf.Get(key)
+	if !ok {
+		log.Error(fmt.Errorf("config %s is not set", key))
+		os.Exit(1)
+	}
+	if *props.OutputFormatJSON {
+		bytes, err := json.MarshalIndent(config.Entry{Key: key, Value: value}, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal the config: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+	} else {
+		_, _ = fmt.Fprintln(out, value)
+	}
+}
+
+func configSetRun(out io.Writer, props *ConfigProps, opts *ConfigSetOption, key string, value string) {
+	path, err := configFilePath(*props.ConfigPath, opts.Local)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Config file: " + path)
+
+	conf, err := config.ReadFile(path)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	err = conf.Set(key, value)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	err = config.WriteFile(path, conf)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	if !*props.OutputFormatJSON {
+		_, _ = fmt.Fprintf(out, "%s=%s is set in %s.\n", key, value, path)
+	}
+}
+
+// configFilePath returns the path of the config file to write: the given path, the local one, or the global one.
+func configFilePath(configPath string, local bool) (string, error) {
+	switch {
+	case len(configPath) > 0:
+		return configPath, nil
+	case local:
+		path, ok := config.LocalPath()
+		if !ok {
+			return "", errors.New("not in a git repository")
+		}
+		return path, nil
+	default:
+		path, err := config.GlobalPath()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the config file: %w", err)
+		}
+		return path, nil
+	}
+}
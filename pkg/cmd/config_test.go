@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	got := runCmd(t, server, "config", "set", "owner", "acme", "--config", path)
+	assertOutput(t, "owner=acme is set in "+path+".\n", got)
+	runCmd(t, server, "config", "set", "presets.sprint.query", "in-progress", "--config", path)
+	runCmd(t, server, "config", "set", "queries.in-progress", `Item.Fields.Status.Name == "In progress"`, "--config", path)
+
+	got = runCmd(t, server, "config", "get", "presets.sprint.query", "--config", path)
+	assertOutput(t, "in-progress\n", got)
+
+	got = runCmd(t, server, "config", "list", "--config", path)
+	want := "" +
+		"owner=acme\n" +
+		"presets.sprint.query=in-progress\n" +
+		`queries.in-progress=Item.Fields.Status.Name == "In progress"` + "\n"
+	assertOutput(t, want, got)
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "" +
+		"owner: acme\n" +
+		"queries:\n" +
+		`    in-progress: Item.Fields.Status.Name == "In progress"` + "\n" +
+		"presets:\n" +
+		"    sprint:\n" +
+		"        query: in-progress\n"
+	assertOutput(t, want, string(bytes))
+}
+
+func TestConfigPreset(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	err := os.WriteFile(path, []byte(""+
+		"owner: acme\n"+
+		"project: 1\n"+
+		"field: Sprint\n"+
+		"queries:\n"+
+		`  in-progress: Item.Fields.Status.Name == "In progress"`+"\n"+
+		"presets:\n"+
+		"  sprint:\n"+
+		"    query: in-progress\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newServer(t)
+	got := runCmd(t, server, "items-list", "--preset", "sprint", "--config", path)
+	want := "" +
+		"ID      Title                   Type\n" +
+		"PVTI_1  Fix login bug           ISSUE\n" +
+		"PVTI_3  Refactor API client     PULL_REQUEST\n" +
+		"PVTI_6  Investigate flaky test  ISSUE\n" +
+		"3 items matched.\n"
+	assertOutput(t, want, got)
+
+	got = runCmd(t, server, "items-list", "--preset", "sprint", "--query", `Item.ID == "PVTI_2"`, "--config", path)
+	want = "" +
+		"ID      Title          Type\n" +
+		"PVTI_2  Add dark mode  ISSUE\n" +
+		"1 items matched.\n"
+	assertOutput(t, want, got)
+
+	got = runCmd(t, server, "items-edit", "--preset", "in-progress", "--clear", "--dry-run", "--config", path)
+	want = "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => DryRun.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => DryRun.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> (none)) => No need to update. Skipped.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+}
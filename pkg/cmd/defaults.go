@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/config"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// defaultEnvs are the environment variables supplying the flags not given on the command line.
+//
+//nolint:gochecknoglobals
+var defaultEnvs = map[string]string{
+	"owner":   "GH_ITERATION_OWNER",
+	"project": "GH_ITERATION_PROJECT",
+	"field":   "GH_ITERATION_FIELD",
+}
+
+// defaultSources returns the sources of the flags not given on the command line, in the order of precedence:
+// the preset if it is named, the environment variables, the config file, and the owner of the current repository.
+func defaultSources(configPath string, presetName string) ([]flags.Source, error) {
+	conf, err := config.Load(configPath)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var sources []flags.Source
+	if len(presetName) > 0 {
+		preset, err := conf.Preset(presetName)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		sources = append(sources, valuesSource("preset "+presetName, preset))
+	}
+	return append(sources,
+		flags.Source{
+			Name: "the environment variable",
+			Lookup: func(flag string) (string, bool) {
+				env, ok := defaultEnvs[flag]
+				if !ok {
+					return "", false
+				}
+				value := os.Getenv(env)
+				return value, len(value) > 0
+			},
+		},
+		valuesSource("the config file", conf.Defaults()),
+		repositoryOwnerSource(),
+	), nil
+}
+
+// validateFlags validates the flags of the command by the node, setting the flags not given on the command line
+// from the default sources of defaultSources. The explicit flags are not set from them.
+func validateFlags(cmd *cobra.Command, configPath string, presetName string, node flags.Node, explicit ...string) error {
+	sources, err := defaultSources(configPath, presetName)
+	if err != nil {
+		return err
+	}
+	err = flags.NewValidator(node, sources...).Explicit(explicit...).Validate(cmd)
+	if err != nil {
+		return fmt.Errorf("flags: %w", err)
+	}
+	return nil
+}
+
+// validateProjectFlags validates the flags of a command selecting a project as validateFlags does,
+// taking --project-url from the project URL argument, and then resolves the project URL of the selector.
+func validateProjectFlags(
+	cmd *cobra.Command, args []string, configPath string, presetName string, selector *ProjectSelector, node flags.Node, explicit ...string,
+) error {
+	err := setProjectURLArg(cmd, args)
+	if err != nil {
+		return fmt.Errorf("flags: %w", err)
+	}
+	err = validateFlags(cmd, configPath, presetName, node, explicit...)
+	if err != nil {
+		return err
+	}
+	err = selector.resolveURL()
+	if err != nil {
+		return fmt.Errorf("flags: %w", err)
+	}
+	return nil
+}
+
+func valuesSource(name string, values map[string]string) flags.Source {
+	return flags.Source{
+		Name: name,
+		Lookup: func(flag string) (string, bool) {
+			value, ok := values[flag]
+			return value, ok
+		},
+	}
+}
+
+// repositoryOwnerSource returns the source of the owner of the current repository, given by GH_REPO or the git remotes.
+func repositoryOwnerSource() flags.Source {
+	currentOwner := sync.OnceValue(func() string {
+		repo, err := repository.Current()
+		if err != nil {
+			log.Debug(fmt.Sprintf("No current repository: %v", err))
+			return ""
+		}
+		return repo.Owner
+	})
+	return flags.Source{
+		Name: "the current repository",
+		Lookup: func(flag string) (string, bool) {
+			if flag != "owner" {
+				return "", false
+			}
+			owner := currentOwner()
+			return owner, len(owner) > 0
+		},
+	}
+}
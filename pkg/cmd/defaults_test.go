@@ -0,0 +1,69 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+const wantIterations = "" +
+	"Title     StartDate   Duration  ID      \n" +
+	"Sprint 3  2026-10-05        14  sprint_3\n" +
+	"Sprint 4  2026-10-19        14  sprint_4\n" +
+	"Sprint 5  2026-11-02        14  sprint_5\n"
+
+//nolint:paralleltest
+func TestDefaultsFromEnvironment(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GH_ITERATION_OWNER", "acme")
+	t.Setenv("GH_ITERATION_PROJECT", "1")
+	t.Setenv("GH_ITERATION_FIELD", "Sprint")
+
+	server := newServer(t)
+	got := runCmd(t, server, "list")
+	assertOutput(t, wantIterations, got)
+
+	got = runCmd(t, server, "list", "--owner", "octocat", "--project", "3", "--field", "Week")
+	want := "" +
+		"Title   StartDate   Duration  ID      \n" +
+		"Week 1  2026-10-12         7  week_1  \n" +
+		"Week 2  2026-10-19         7  week_2  \n"
+	assertOutput(t, want, got)
+}
+
+//nolint:paralleltest
+func TestDefaultsFromRepository(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GH_REPO", "acme/app")
+
+	server := newServer(t)
+	got := runCmd(t, server, "list", "--project", "1", "--field", "Sprint")
+	assertOutput(t, wantIterations, got)
+}
+
+//nolint:paralleltest
+func TestDefaultsNotForFieldCreate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GH_ITERATION_FIELD", "Sprint")
+
+	_, code := runCmdInSubprocess(t, func(*githubtest.Server) {}, "field-create", "--owner", "acme", "--project", "1")
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+}
+
+func TestDefaultsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	err := os.WriteFile(path, []byte("owner: acme\nproject: 1\nfield: Sprint\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newServer(t)
+	got := runCmd(t, server, "list", "--config", path)
+	assertOutput(t, wantIterations, got)
+}
@@ -0,0 +1,10 @@
+package cmd
+
+//nolint:gochecknoglobals
+var (
+	CurrentIteration     = currentIteration
+	RelativeIteration    = relativeIteration
+	FindIterationByTitle = findIterationByTitle
+	Confirm              = confirm
+	ParseProjectURL      = parseProjectURL
+)
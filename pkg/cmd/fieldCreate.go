@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type FieldCreateProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type FieldCreateOption struct {
+	ProjectSelector
+
+	FieldName string
+	StartDate string
+	Duration  int
+	Count     int
+	Title     string
+}
+
+const (
+	defaultIterationDuration = 14
+	defaultIterationCount    = 3
+)
+
+func NewFieldCreateCmd(props *FieldCreateProps) *cobra.Command {
+	opts := new(FieldCreateOption)
+
+	// fieldCreateCmd represents the field-create command.
+	fieldCreateCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "field-create" + projectURLArgs,
+		Short: "Create an iteration field",
+		Long: `Create an iteration field in a project with its initial iterations.
+"{n}" in the title is replaced with the number of the iteration.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// The field to create is not the default field.
+			err := validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					flags.Flag("field"),
+					projectFlags(),
+				),
+				"field",
+			)
+			if err != nil {
+				return err
+			}
+			if len(opts.StartDate) > 0 {
+				_, err = time.Parse(time.DateOnly, opts.StartDate)
+				if err != nil {
+					return fmt.Errorf("flags: invalid start date: %w", err)
+				}
+			}
+			if opts.Duration < 1 {
+				return fmt.Errorf("flags: duration must be positive: %d", opts.Duration)
+			}
+			if opts.Count < 0 {
+				return fmt.Errorf("flags: count must not be negative: %d", opts.Count)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			fieldCreateRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	fieldCreateCmd.Flags().SortFlags = false
+	fieldCreateCmd.Flags().StringVar(&opts.FieldName, "field", "", "Name of the iteration field to create")
+	fieldCreateCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	fieldCreateCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	fieldCreateCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	fieldCreateCmd.Flags().StringVar(&opts.StartDate, "start-date", "", "Start date (YYYY-MM-DD) of the first iteration (default: today)")
+	fieldCreateCmd.Flags().IntVar(&opts.Duration, "duration", defaultIterationDuration, "Duration of the iterations in days")
+	fieldCreateCmd.Flags().IntVar(&opts.Count, "count", defaultIterationCount, "Number of the initial iterations")
+	fieldCreateCmd.Flags().StringVar(&opts.Title, "title", "Iteration "+iterationNumberPlaceholder, "Title of the initial iterations")
+
+	return fieldCreateCmd
+}
+
+func fieldCreateRun(out io.Writer, props *FieldCreateProps, opts *FieldCreateOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	startDate := time.Now()
+	if len(opts.StartDate) > 0 {
+		startDate, err = time.Parse(time.DateOnly, opts.StartDate)
+		if err != nil {
+			log.Error(fmt.Errorf("invalid start date: %w", err))
+			os.Exit(1)
+		}
+	}
+
+	log.Debug("Create an iteration field")
+	field, err := client.CreateIterationField(project.ID, opts.FieldName, github.ProjectV2IterationFieldConfigurationInput{
+		Duration:   opts.Duration,
+		Iterations: consecutiveIterations(startDate, opts.Duration, opts.Count, opts.Title, 1),
+		StartDate:  startDate.Format(time.DateOnly),
+	})
+	if err != nil {
+		log.Error(fmt.Errorf("failed to create an iteration field: %w", err))
+		os.Exit(1)
+	}
+	log.Debug("Iteration field ID: " + field.ID)
+
+	if *props.OutputFormatJSON {
+		bytes, err := json.MarshalIndent(field, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal iteration field: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprint(out, string(bytes))
+	} else {
+		s := formatIterationFieldPlain(field, today())
+		_, _ = fmt.Fprint(out, s)
+	}
+}
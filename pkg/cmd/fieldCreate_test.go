@@ -0,0 +1,64 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestFieldCreate(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"field-create", "--owner", "acme", "--project", "1", "--field", "Cycle",
+		"--start-date", "2026-10-05", "--duration", "7", "--count", "3", "--title", "Cycle {n}", "--json")
+	want := `{
+  "id": "PVTIF_1",
+  "name": "Cycle",
+  "configuration": {
+    "completedIterations": [
+      {
+        "duration": 7,
+        "id": "iteration_2",
+        "startDate": "2026-10-05",
+        "title": "Cycle 1"
+      }
+    ],
+    "iterations": [
+      {
+        "duration": 7,
+        "id": "iteration_3",
+        "startDate": "2026-10-12",
+        "title": "Cycle 2"
+      },
+      {
+        "duration": 7,
+        "id": "iteration_4",
+        "startDate": "2026-10-19",
+        "title": "Cycle 3"
+      }
+    ],
+    "duration": 7,
+    "startDate": "2026-10-05"
+  }
+}`
+	assertOutput(t, want, got)
+
+	got = runCmd(t, server, "list", "--owner", "acme", "--project", "1", "--field", "Cycle", "--json")
+	want = `{
+  "iterations": [
+    {
+      "id": "iteration_3",
+      "title": "Cycle 2",
+      "startDate": "2026-10-12",
+      "duration": 7
+    },
+    {
+      "id": "iteration_4",
+      "title": "Cycle 3",
+      "startDate": "2026-10-19",
+      "duration": 7
+    }
+  ]
+}`
+	assertOutput(t, want, got)
+}
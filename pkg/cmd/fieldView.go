@@ -3,8 +3,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tasshi-me/gh-iteration/pkg/flags"
@@ -14,12 +16,15 @@ import (
 
 type FieldViewProps struct {
 	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
 }
 
 type FieldViewOption struct {
-	ProjectOwner  string
-	ProjectNumber int
-	FieldName     string
+	ProjectSelector
+
+	FieldName string
+	Date      string
 }
 
 func NewFieldViewCmd(props *FieldViewProps) *cobra.Command {
@@ -27,26 +32,20 @@ func NewFieldViewCmd(props *FieldViewProps) *cobra.Command {
 
 	// fieldListCmd represents the field-list command.
 	fieldListCmd := &cobra.Command{ //nolint:exhaustruct
-		Use:   "field-view",
+		Use:   "field-view" + projectURLArgs,
 		Short: "View an iteration field",
 		Long:  `View an iteration field`,
-		Args:  cobra.NoArgs,
-		PreRunE: func(cmd *cobra.Command, _ []string) error {
-			validator := flags.NewValidator(
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
 				flags.And(
 					flags.Flag("field"),
-					flags.Flag("project"),
-					flags.Flag("owner"),
+					projectFlags(),
 				),
 			)
-			err := validator.Validate(cmd)
-			if err != nil {
-				return fmt.Errorf("flags: %w", err)
-			}
-			return nil
 		},
-		Run: func(_ *cobra.Command, _ []string) {
-			fieldViewRun(props, opts)
+		Run: func(cmd *cobra.Command, _ []string) {
+			fieldViewRun(cmd.OutOrStdout(), props, opts)
 		},
 	}
 
@@ -54,59 +53,66 @@ func NewFieldViewCmd(props *FieldViewProps) *cobra.Command {
 	fieldListCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
 	fieldListCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
 	fieldListCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
-	_ = fieldListCmd.MarkFlagRequired("field")
-	_ = fieldListCmd.MarkFlagRequired("project")
-	_ = fieldListCmd.MarkFlagRequired("owner")
+	fieldListCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	fieldListCmd.Flags().StringVar(&opts.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration (default: today in the local time zone)")
 
 	return fieldListCmd
 }
 
-func fieldViewRun(props *FieldViewProps, opts *FieldViewOption) {
-	log.Debug("Retrieve owner by login name")
-	projectOwner, err := github.FetchOwnerByLogin(opts.ProjectOwner)
+func fieldViewRun(out io.Writer, props *FieldViewProps, opts *FieldViewOption) {
+	client, err := props.NewClient()
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve owner by owner login: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Owner: " + projectOwner.Login)
 
-	log.Debug("Retrieve project by owner and project number")
-	project, err := github.FetchProjectByNumber(opts.ProjectNumber, projectOwner.ID)
+	project, err := retrieveProject(client, &opts.ProjectSelector)
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve a project by project number: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Project ID: " + project.ID)
 
 	log.Debug("Retrieve an iteration field by field name and project")
-	field, err := github.FetchIterationFieldByName(project.ID, opts.FieldName)
+	field, err := client.FetchIterationFieldByName(project.ID, opts.FieldName)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err))
 		os.Exit(1)
 	}
 
+	date, err := resolveDate(opts.Date)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
 	if *props.OutputFormatJSON {
 		bytes, err := json.MarshalIndent(field, "", "  ")
 		if err != nil {
 			log.Error(fmt.Errorf("failed to marshal iterations: %w", err))
 			os.Exit(1)
 		}
-		_, _ = fmt.Fprint(os.Stdout, string(bytes))
+		_, _ = fmt.Fprint(out, string(bytes))
 	} else {
-		s := formatIterationFieldPlain(field)
-		_, _ = fmt.Fprint(os.Stdout, s)
+		s := formatIterationFieldPlain(field, date)
+		_, _ = fmt.Fprint(out, s)
 	}
 }
 
-func formatIterationFieldPlain(field *github.ProjectV2IterationField) string {
-	currentIteration := field.Configuration.Iterations[0]
+func formatIterationFieldPlain(field *github.ProjectV2IterationField, date time.Time) string {
+	var current github.ProjectV2IterationFieldIteration
+	if iteration, err := currentIteration(field, date); err == nil {
+		current = *iteration
+	} else {
+		log.Debug(err.Error())
+	}
 
 	maxFieldNameLen := len(field.Name)
 	maxFieldIDLen := len(field.ID)
-	maxTitleLen := len(currentIteration.Title)
+	maxTitleLen := len(current.Title)
 
 	format := "%-" + strconv.Itoa(maxFieldNameLen) + "s  %-" + strconv.Itoa(maxFieldIDLen) + "s  %-" + strconv.Itoa(maxTitleLen) + "s  %-10s\n"
 	str := fmt.Sprintf(format, "Name", "ID", "Current", "StartDate")
-	str += fmt.Sprintf(format, field.Name, field.ID, currentIteration.Title, currentIteration.StartDate)
+	str += fmt.Sprintf(format, field.Name, field.ID, current.Title, current.StartDate)
 	return str
 }
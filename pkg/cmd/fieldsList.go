@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -15,11 +16,12 @@ import (
 
 type FieldListProps struct {
 	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
 }
 
 type FieldListOption struct {
-	ProjectOwner  string
-	ProjectNumber int
+	ProjectSelector
 }
 
 func NewFieldListCmd(props *FieldListProps) *cobra.Command {
@@ -27,56 +29,45 @@ func NewFieldListCmd(props *FieldListProps) *cobra.Command {
 
 	// fieldListCmd represents the field-list command.
 	fieldListCmd := &cobra.Command{ //nolint:exhaustruct
-		Use:   "field-list",
+		Use:   "field-list" + projectURLArgs,
 		Short: "List the iteration fields in a project",
 		Long:  `List the iteration fields in a project`,
-		Args:  cobra.NoArgs,
-		PreRunE: func(cmd *cobra.Command, _ []string) error {
-			validator := flags.NewValidator(
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
 				flags.And(
-					flags.Flag("project"),
-					flags.Flag("owner"),
+					projectFlags(),
 				),
 			)
-			err := validator.Validate(cmd)
-			if err != nil {
-				return fmt.Errorf("flags: %w", err)
-			}
-			return nil
 		},
-		Run: func(_ *cobra.Command, _ []string) {
-			fieldListRun(props, opts)
+		Run: func(cmd *cobra.Command, _ []string) {
+			fieldListRun(cmd.OutOrStdout(), props, opts)
 		},
 	}
 
 	fieldListCmd.Flags().SortFlags = false
 	fieldListCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
 	fieldListCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
-	_ = fieldListCmd.MarkFlagRequired("project")
-	_ = fieldListCmd.MarkFlagRequired("owner")
+	fieldListCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
 
 	return fieldListCmd
 }
 
-func fieldListRun(props *FieldListProps, opts *FieldListOption) {
-	log.Debug("Retrieve owner by login name")
-	projectOwner, err := github.FetchOwnerByLogin(opts.ProjectOwner)
+func fieldListRun(out io.Writer, props *FieldListProps, opts *FieldListOption) {
+	client, err := props.NewClient()
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve owner by owner login: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Owner: " + projectOwner.Login)
 
-	log.Debug("Retrieve project by owner and project number")
-	project, err := github.FetchProjectByNumber(opts.ProjectNumber, projectOwner.ID)
+	project, err := retrieveProject(client, &opts.ProjectSelector)
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve a project by project number: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Project ID: " + project.ID)
 
 	log.Debug("Retrieve an iteration field by field name and project")
-	fields, err := github.FetchIterationFields(project.ID)
+	fields, err := client.FetchIterationFields(project.ID)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err))
 		os.Exit(1)
@@ -88,10 +79,10 @@ func fieldListRun(props *FieldListProps, opts *FieldListOption) {
 			log.Error(fmt.Errorf("failed to marshal iterations: %w", err))
 			os.Exit(1)
 		}
-		_, _ = fmt.Fprint(os.Stdout, string(bytes))
+		_, _ = fmt.Fprint(out, string(bytes))
 	} else {
 		s := formatIterationFieldsPlain(fields)
-		_, _ = fmt.Fprint(os.Stdout, s)
+		_, _ = fmt.Fprint(out, s)
 	}
 }
 
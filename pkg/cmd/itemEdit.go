@@ -3,23 +3,27 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
 	"github.com/tasshi-me/gh-iteration/pkg/github"
 	"github.com/tasshi-me/gh-iteration/pkg/log"
 )
 
 type ItemEditProps struct {
 	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
 }
 
 type ItemEditOption struct {
-	FieldName      string
-	ID             string
-	Clear          bool
-	Current        bool
-	IterationTitle string
+	IterationSelector
+
+	FieldName string
+	ID        string
+	Clear     bool
 }
 
 func NewItemEditCmd(props *ItemEditProps) *cobra.Command {
@@ -31,8 +35,16 @@ func NewItemEditCmd(props *ItemEditProps) *cobra.Command {
 		Short: "Edit iteration of a project item",
 		Long:  `Edit iteration of a project item`,
 		Args:  cobra.NoArgs,
-		Run: func(_ *cobra.Command, _ []string) {
-			itemEditRun(props, opts)
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return validateFlags(cmd, *props.ConfigPath, "",
+				flags.And(
+					flags.Flag("id"),
+					flags.Flag("field"),
+				),
+			)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			itemEditRun(cmd.OutOrStdout(), props, opts)
 		},
 	}
 
@@ -40,19 +52,23 @@ func NewItemEditCmd(props *ItemEditProps) *cobra.Command {
 	fieldEditCmd.Flags().StringVar(&opts.ID, "id", "", "ID of the project item to edit")
 	fieldEditCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
 	fieldEditCmd.Flags().BoolVar(&opts.Clear, "clear", false, "Clear iteration field value")
-	fieldEditCmd.Flags().BoolVar(&opts.Current, "current", false, "Set current iteration as the iteration field value")
-	fieldEditCmd.Flags().StringVar(&opts.IterationTitle, "iteration", "", "Iteration title to set")
-	fieldEditCmd.MarkFlagsOneRequired("clear", "current", "iteration")
-	_ = fieldEditCmd.MarkFlagRequired("id")
-	_ = fieldEditCmd.MarkFlagRequired("field")
+	addIterationSelectorFlags(fieldEditCmd, &opts.IterationSelector)
+	fieldEditCmd.MarkFlagsOneRequired(append([]string{"clear"}, iterationSelectorFlags...)...)
+	fieldEditCmd.MarkFlagsMutuallyExclusive(append([]string{"clear"}, iterationSelectorFlags...)...)
 
 	return fieldEditCmd
 }
 
-//nolint:funlen,gocognit,cyclop
-func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
+//nolint:funlen,cyclop
+func itemEditRun(out io.Writer, props *ItemEditProps, opts *ItemEditOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
 	log.Debug("Retrieve project item by ID")
-	githubItem, err := github.FetchProjectItem(opts.ID)
+	githubItem, err := client.FetchProjectItem(opts.ID)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve a project item by item id: %w", err))
 		os.Exit(1)
@@ -65,7 +81,7 @@ func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
 	log.Debug("Item name: " + item.Title)
 
 	log.Debug("Retrieve an iteration field by field name and project")
-	iterationField, err := github.FetchIterationFieldByName(project.ID, opts.FieldName)
+	iterationField, err := client.FetchIterationFieldByName(project.ID, opts.FieldName)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err))
 		os.Exit(1)
@@ -74,52 +90,28 @@ func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
 	var updatedID string
 	skipped := false
 
-	iterationIDFromCurrentItem := ""
-	if item.Fields[opts.FieldName] != nil {
-		iterationFromCurrentItem, ok := item.Fields[opts.FieldName].(FieldIteration)
-		if ok {
-			iterationIDFromCurrentItem = iterationFromCurrentItem.IterationID
+	previous := itemIteration(item, opts.FieldName)
+	iterationIDFromCurrentItem := previous.IterationID
+
+	var iteration *github.ProjectV2IterationFieldIteration
+	if opts.isSet() {
+		iteration, err = opts.resolve(iterationField)
+		if err != nil {
+			log.Error(fmt.Errorf("failed to resolve the iteration: %w", err))
+			os.Exit(1)
 		}
 	}
 
 	log.Debug("Update an iteration field")
 	switch {
-	case opts.Current:
+	case iteration != nil:
 		{
-			log.Debug("Update iteration field to current sprint")
-			currentIteration := iterationField.Configuration.Iterations[0]
-			log.Debug("current sprint: " + currentIteration.Title)
-
-			if iterationIDFromCurrentItem == currentIteration.ID {
+			log.Debug("Update iteration field to sprint: " + iteration.Title)
+			if iterationIDFromCurrentItem == iteration.ID {
 				log.Debug("No need to update. Skip.")
 				skipped = true
 			} else {
-				updatedID, err = github.UpdateIterationField(project.ID, iterationField.ID, item.ID, currentIteration.ID)
-			}
-		}
-	case len(opts.IterationTitle) > 0:
-		{
-			log.Debug("Update iteration field to specified sprint:" + opts.IterationTitle)
-			var iteration github.ProjectV2IterationFieldIteration
-			for _, iter := range iterationField.Configuration.Iterations {
-				if iter.Title == opts.IterationTitle {
-					iteration = iter
-				}
-			}
-			for _, iter := range iterationField.Configuration.CompletedIterations {
-				if iter.Title == opts.IterationTitle {
-					iteration = iter
-				}
-			}
-			if len(iteration.ID) > 0 {
-				if iterationIDFromCurrentItem == iteration.ID {
-					log.Debug("No need to update. Skip.")
-					skipped = true
-				} else {
-					updatedID, err = github.UpdateIterationField(project.ID, iterationField.ID, item.ID, iteration.ID)
-				}
-			} else {
-				err = fmt.Errorf("cannot find specified iteration: %s", opts.IterationTitle)
+				updatedID, err = client.UpdateIterationField(project.ID, iterationField.ID, item.ID, iteration.ID)
 			}
 		}
 	case opts.Clear:
@@ -129,7 +121,7 @@ func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
 				log.Debug("No need to update. Skip.")
 				skipped = true
 			} else {
-				updatedID, err = github.ClearIterationField(project.ID, iterationField.ID, item.ID)
+				updatedID, err = client.ClearIterationField(project.ID, iterationField.ID, item.ID)
 			}
 		}
 	}
@@ -139,10 +131,26 @@ func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
 		os.Exit(1)
 	}
 
+	newIterationID, newIterationTitle := "", ""
+	if iteration != nil {
+		newIterationID, newIterationTitle = iteration.ID, iteration.Title
+	}
 	result := struct {
-		ID      string `json:"id"`
-		Skipped bool   `json:"skipped"`
-	}{ID: updatedID, Skipped: skipped}
+		ID                     string `json:"id"`
+		PreviousIterationID    string `json:"previousIterationId"`
+		PreviousIterationTitle string `json:"previousIterationTitle"`
+		NewIterationID         string `json:"newIterationId"`
+		NewIterationTitle      string `json:"newIterationTitle"`
+		Skipped                bool   `json:"skipped"`
+	}{
+		ID:                     updatedID,
+		PreviousIterationID:    previous.IterationID,
+		PreviousIterationTitle: previous.Title,
+		NewIterationID:         newIterationID,
+		NewIterationTitle:      newIterationTitle,
+		Skipped:                skipped,
+	}
+	change := formatIterationChange(result.PreviousIterationTitle, result.NewIterationTitle)
 
 	if *props.OutputFormatJSON {
 		bytes, err := json.MarshalIndent(result, "", "  ")
@@ -150,12 +158,12 @@ func itemEditRun(props *ItemEditProps, opts *ItemEditOption) {
 			log.Error(fmt.Errorf("failed to marshal result: %w", err))
 			os.Exit(1)
 		}
-		_, _ = fmt.Fprint(os.Stdout, string(bytes))
+		_, _ = fmt.Fprint(out, string(bytes))
 	} else {
 		if skipped {
-			_, _ = fmt.Fprint(os.Stdout, "No need to update. Skipped.")
+			_, _ = fmt.Fprint(out, "No need to update. Skipped. "+change)
 		} else {
-			_, _ = fmt.Fprint(os.Stdout, result.ID)
+			_, _ = fmt.Fprint(out, result.ID+" "+change)
 		}
 	}
 }
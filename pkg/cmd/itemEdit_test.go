@@ -0,0 +1,94 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestItemEdit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		args      []string
+		want      string
+		itemID    string
+		wantValue any
+	}{
+		{
+			name:      "set iteration by title",
+			args:      []string{"item-edit", "--id", "PVTI_4", "--field", "Sprint", "--iteration", "Sprint 4"},
+			want:      "PVTI_4 ((none) -> Sprint 4)",
+			itemID:    "PVTI_4",
+			wantValue: "sprint_4",
+		},
+		{
+			name:      "set current iteration",
+			args:      []string{"item-edit", "--id", "PVTI_1", "--field", "Sprint", "--current", "--date", fixtureToday},
+			want:      "PVTI_1 (Sprint 2 -> Sprint 3)",
+			itemID:    "PVTI_1",
+			wantValue: "sprint_3",
+		},
+		{
+			name:      "set iteration current on the date",
+			args:      []string{"item-edit", "--id", "PVTI_1", "--field", "Sprint", "--current", "--date", "2026-10-25"},
+			want:      "PVTI_1 (Sprint 2 -> Sprint 4)",
+			itemID:    "PVTI_1",
+			wantValue: "sprint_4",
+		},
+		{
+			name:      "set previous iteration",
+			args:      []string{"item-edit", "--id", "PVTI_3", "--field", "Sprint", "--previous", "--date", fixtureToday},
+			want:      "PVTI_3 (Sprint 3 -> Sprint 2)",
+			itemID:    "PVTI_3",
+			wantValue: "sprint_2",
+		},
+		{
+			name:      "set next iteration",
+			args:      []string{"item-edit", "--id", "PVTI_3", "--field", "Sprint", "--next", "--date", fixtureToday},
+			want:      "PVTI_3 (Sprint 3 -> Sprint 4)",
+			itemID:    "PVTI_3",
+			wantValue: "sprint_4",
+		},
+		{
+			name:      "set iteration by ID",
+			args:      []string{"item-edit", "--id", "PVTI_6", "--field", "Sprint", "--iteration-id", "sprint_1"},
+			want:      "PVTI_6 ((none) -> Sprint 1)",
+			itemID:    "PVTI_6",
+			wantValue: "sprint_1",
+		},
+		{
+			name:      "set iteration by date",
+			args:      []string{"item-edit", "--id", "PVTI_6", "--field", "Sprint", "--iteration-date", "2026-11-15"},
+			want:      "PVTI_6 ((none) -> Sprint 5)",
+			itemID:    "PVTI_6",
+			wantValue: "sprint_5",
+		},
+		{
+			name:      "skip current iteration",
+			args:      []string{"item-edit", "--id", "PVTI_3", "--field", "Sprint", "--current", "--date", fixtureToday},
+			want:      "No need to update. Skipped. (Sprint 3 -> Sprint 3)",
+			itemID:    "PVTI_3",
+			wantValue: "sprint_3",
+		},
+		{
+			name: "clear iteration",
+			args: []string{"item-edit", "--id", "PVTI_5", "--field", "Sprint", "--clear", "--json"},
+			want: "{\n  \"id\": \"PVTI_5\",\n  \"previousIterationId\": \"sprint_4\",\n  \"previousIterationTitle\": \"Sprint 4\",\n" +
+				"  \"newIterationId\": \"\",\n  \"newIterationTitle\": \"\",\n  \"skipped\": false\n}",
+			itemID:    "PVTI_5",
+			wantValue: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server, test.args...)
+			assertOutput(t, test.want, got)
+			assertFieldValue(t, server, test.itemID, "Sprint", test.wantValue)
+		})
+	}
+}
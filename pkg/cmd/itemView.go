@@ -3,8 +3,8 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 
 	graphql "github.com/cli/shurcooL-graphql"
 	"github.com/spf13/cobra"
@@ -14,6 +14,7 @@ import (
 
 type ItemViewProps struct {
 	OutputFormatJSON *bool
+	NewClient        ClientFactory
 }
 
 type ItemViewOption struct {
@@ -29,8 +30,8 @@ func NewItemViewCmd(props *ItemViewProps) *cobra.Command {
 		Short: "View a project item",
 		Long:  `View a project item`,
 		Args:  cobra.NoArgs,
-		Run: func(_ *cobra.Command, _ []string) {
-			itemViewRun(props, opts)
+		Run: func(cmd *cobra.Command, _ []string) {
+			itemViewRun(cmd.OutOrStdout(), props, opts)
 		},
 	}
 
@@ -41,9 +42,15 @@ func NewItemViewCmd(props *ItemViewProps) *cobra.Command {
 	return fieldViewCmd
 }
 
-func itemViewRun(props *ItemViewProps, opts *ItemViewOption) {
+func itemViewRun(out io.Writer, props *ItemViewProps, opts *ItemViewOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
 	log.Debug("Retrieve project item by ID")
-	githubItem, err := github.FetchProjectItem(opts.ID)
+	githubItem, err := client.FetchProjectItem(opts.ID)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve a project item by item id: %w", err))
 		os.Exit(1)
@@ -58,34 +65,34 @@ func itemViewRun(props *ItemViewProps, opts *ItemViewOption) {
 			log.Error(fmt.Errorf("failed to marshal iterations: %w", err))
 			os.Exit(1)
 		}
-		_, _ = fmt.Fprint(os.Stdout, string(bytes))
+		_, _ = fmt.Fprint(out, string(bytes))
 	} else {
 		s := formatItemPlain(&item)
-		_, _ = fmt.Fprint(os.Stdout, s)
+		_, _ = fmt.Fprint(out, s)
 	}
 }
 
 func formatItemPlain(item *ProjectItem) string {
-	repoLen := max(len(item.Repository), len("Repo"))
-	numLen := max(len(strconv.Itoa(item.Number)), len("Number"))
-	IDLen := max(len(item.ID), len("ID"))
-	titleLen := max(len(item.Title), len("Title"))
-
-	//nolint:lll
-	format := "%-" + strconv.Itoa(repoLen) + "s  %-" + strconv.Itoa(numLen) + "s  %-" + strconv.Itoa(IDLen) + "s  %-" + strconv.Itoa(titleLen) + "s\n"
-	str := fmt.Sprintf(format, "Repo", "Number", "ID", "Title")
-	str += fmt.Sprintf(format, item.Repository, strconv.Itoa(item.Number), item.ID, item.Title)
-	return str
+	return formatItemsTable([]ProjectItem{*item}, []string{"repository", "number", "id", "title", "state", "author", "url"})
 }
 
 type ProjectItem struct {
-	ID         string                 `json:"id"`
-	Title      string                 `json:"title"`
-	Repository string                 `json:"repository"`
-	Number     int                    `json:"number"`
-	Fields     map[string]interface{} `json:"fields"`
-	IsArchived bool                   `json:"isArchived"`
-	Type       string                 `json:"type"` // DRAFT_ISSUE, ISSUE, PULL_REQUEST, REDACTED
+	ID          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	Repository  string                 `json:"repository"`
+	Number      int                    `json:"number"`
+	URL         string                 `json:"url"`
+	State       string                 `json:"state"`       // CLOSED, MERGED, OPEN, empty for draft issues
+	StateReason string                 `json:"stateReason"` // COMPLETED, NOT_PLANNED, REOPENED for issues
+	IsDraft     bool                   `json:"isDraft"`
+	Merged      bool                   `json:"merged"`
+	Author      string                 `json:"author"` // the creator for draft issues
+	CreatedAt   string                 `json:"createdAt"`
+	UpdatedAt   string                 `json:"updatedAt"`
+	ClosedAt    string                 `json:"closedAt"`
+	Fields      map[string]interface{} `json:"fields"`
+	IsArchived  bool                   `json:"isArchived"`
+	Type        string                 `json:"type"` // DRAFT_ISSUE, ISSUE, PULL_REQUEST, REDACTED
 }
 
 type FieldCommon struct {
@@ -112,40 +119,113 @@ type FieldSingleSelect struct {
 	OptionID  string `json:"optionId"`
 }
 
+type FieldDate struct {
+	FieldType string `json:"fieldType"`
+	Date      string `json:"date"`
+}
+
+type FieldText struct {
+	FieldType string `json:"fieldType"`
+	Text      string `json:"text"`
+}
+
+type FieldLabels struct {
+	FieldType string   `json:"fieldType"`
+	Names     []string `json:"names"`
+}
+
+type FieldUsers struct {
+	FieldType string   `json:"fieldType"`
+	Logins    []string `json:"logins"`
+}
+
+type FieldMilestone struct {
+	FieldType string `json:"fieldType"`
+	Title     string `json:"title"`
+}
+
+// FieldReviewers holds the logins of the requested users and the "org/slug" of the requested teams.
+type FieldReviewers struct {
+	FieldType string   `json:"fieldType"`
+	Logins    []string `json:"logins"`
+}
+
+type FieldRepository struct {
+	FieldType     string `json:"fieldType"`
+	Name          string `json:"name"`
+	NameWithOwner string `json:"nameWithOwner"`
+}
+
+type FieldPullRequests struct {
+	FieldType string `json:"fieldType"`
+	Numbers   []int  `json:"numbers"`
+}
+
 func ConvertGitHubProjectItem(item *github.ProjectItem) ProjectItem {
-	itemID := item.ID
-	title := item.Content.DraftIssue.Title
-	repository := item.Content.Issue.Repository.NameWithOwner
-	number := item.Content.Issue.Number
 	fields := map[string]interface{}{}
 	for _, fieldValue := range item.FieldValues.Nodes {
-		fieldName := fieldValue.ProjectV2ItemFieldValueCommon.Field.ProjectV2FieldCommon.Name
+		fieldName := fieldValue.Field().ProjectV2FieldCommon.Name
 		fields[fieldName] = ConvertGitHubProjectItemField(fieldValue)
 	}
 
-	isArchived := item.IsArchived
-	ttype := item.Type
-
-	return ProjectItem{
-		ID:         itemID,
-		Title:      title,
-		Repository: repository,
-		Number:     number,
+	converted := ProjectItem{ //nolint:exhaustruct
+		ID:         item.ID,
 		Fields:     fields,
-		IsArchived: isArchived,
-		Type:       ttype,
+		IsArchived: item.IsArchived,
+		Type:       item.Type,
 	}
+
+	// The properties are taken from the content of the item type,
+	// because the decoder fills the fragments of the other content types with the same keys.
+	switch item.Type {
+	case "ISSUE":
+		issue := item.Content.Issue
+		converted.Title = issue.Title
+		converted.Repository = issue.Repository.NameWithOwner
+		converted.Number = issue.Number
+		converted.URL = issue.URL
+		converted.State = issue.State
+		converted.StateReason = issue.StateReason
+		converted.Author = issue.Author.Login
+		converted.CreatedAt = issue.CreatedAt
+		converted.UpdatedAt = issue.UpdatedAt
+		converted.ClosedAt = issue.ClosedAt
+	case "PULL_REQUEST":
+		pullRequest := item.Content.PullRequest
+		converted.Title = pullRequest.Title
+		converted.Repository = pullRequest.Repository.NameWithOwner
+		converted.Number = pullRequest.Number
+		converted.URL = pullRequest.URL
+		converted.State = pullRequest.State
+		converted.IsDraft = pullRequest.IsDraft
+		converted.Merged = pullRequest.Merged
+		converted.Author = pullRequest.Author.Login
+		converted.CreatedAt = pullRequest.CreatedAt
+		converted.UpdatedAt = pullRequest.UpdatedAt
+		converted.ClosedAt = pullRequest.ClosedAt
+	case "DRAFT_ISSUE":
+		draftIssue := item.Content.DraftIssue
+		converted.Title = draftIssue.Title
+		converted.Author = draftIssue.Creator.Login
+		converted.CreatedAt = draftIssue.CreatedAt
+		converted.UpdatedAt = draftIssue.UpdatedAt
+	}
+	return converted
 }
 
 //nolint:cyclop
 func ConvertGitHubProjectItemField(fieldValue github.FieldValue) interface{} {
-	fieldType := fieldValue.ProjectV2ItemFieldValueCommon.Field.ProjectV2FieldCommon.DataType
+	fieldType := fieldValue.Field().ProjectV2FieldCommon.DataType
 
 	switch fieldType {
 	case "ASSIGNEES":
-		return FieldCommon{FieldType: fieldType}
+		logins := make([]string, 0, len(fieldValue.ProjectV2ItemFieldUserValue.Users.Nodes))
+		for _, user := range fieldValue.ProjectV2ItemFieldUserValue.Users.Nodes {
+			logins = append(logins, user.Login)
+		}
+		return FieldUsers{FieldType: fieldType, Logins: logins}
 	case "DATE":
-		return FieldCommon{FieldType: fieldType}
+		return FieldDate{FieldType: fieldType, Date: fieldValue.ProjectV2ItemFieldDateValue.Date}
 	case "ITERATION":
 		return FieldIteration{
 			FieldType:   fieldType,
@@ -156,20 +236,40 @@ func ConvertGitHubProjectItemField(fieldValue github.FieldValue) interface{} {
 			TitleHTML:   fieldValue.ProjectV2ItemFieldIterationValue.TitleHTML,
 		}
 	case "LABELS":
-		return FieldCommon{FieldType: fieldType}
+		names := make([]string, 0, len(fieldValue.ProjectV2ItemFieldLabelValue.Labels.Nodes))
+		for _, label := range fieldValue.ProjectV2ItemFieldLabelValue.Labels.Nodes {
+			names = append(names, label.Name)
+		}
+		return FieldLabels{FieldType: fieldType, Names: names}
 	case "LINKED_PULL_REQUESTS":
-		return FieldCommon{FieldType: fieldType}
+		numbers := make([]int, 0, len(fieldValue.ProjectV2ItemFieldPullRequestValue.PullRequests.Nodes))
+		for _, pullRequest := range fieldValue.ProjectV2ItemFieldPullRequestValue.PullRequests.Nodes {
+			numbers = append(numbers, pullRequest.Number)
+		}
+		return FieldPullRequests{FieldType: fieldType, Numbers: numbers}
 	case "MILESTONE":
-		return FieldCommon{FieldType: fieldType}
+		return FieldMilestone{FieldType: fieldType, Title: fieldValue.ProjectV2ItemFieldMilestoneValue.Milestone.Title}
 	case "NUMBER":
 		return FieldNumber{
 			FieldType: fieldType,
 			Number:    fieldValue.ProjectV2ItemFieldNumberValue.Number,
 		}
 	case "REPOSITORY":
-		return FieldCommon{FieldType: fieldType}
+		return FieldRepository{
+			FieldType:     fieldType,
+			Name:          fieldValue.ProjectV2ItemFieldRepositoryValue.Repository.Name,
+			NameWithOwner: fieldValue.ProjectV2ItemFieldRepositoryValue.Repository.NameWithOwner,
+		}
 	case "REVIEWERS":
-		return FieldCommon{FieldType: fieldType}
+		logins := make([]string, 0, len(fieldValue.ProjectV2ItemFieldReviewerValue.Reviewers.Nodes))
+		for _, reviewer := range fieldValue.ProjectV2ItemFieldReviewerValue.Reviewers.Nodes {
+			if len(reviewer.Team.CombinedSlug) > 0 {
+				logins = append(logins, reviewer.Team.CombinedSlug)
+			} else {
+				logins = append(logins, reviewer.User.Login)
+			}
+		}
+		return FieldReviewers{FieldType: fieldType, Logins: logins}
 	case "SINGLE_SELECT":
 		return FieldSingleSelect{
 			FieldType: fieldType,
@@ -177,9 +277,9 @@ func ConvertGitHubProjectItemField(fieldValue github.FieldValue) interface{} {
 			OptionID:  fieldValue.ProjectV2ItemFieldSingleSelectValue.OptionID,
 		}
 	case "TEXT":
-		return FieldCommon{FieldType: fieldType}
+		return FieldText{FieldType: fieldType, Text: fieldValue.ProjectV2ItemFieldTextValue.Text}
 	case "TITLE":
-		return FieldCommon{FieldType: fieldType}
+		return FieldText{FieldType: fieldType, Text: fieldValue.ProjectV2ItemFieldTextValue.Text}
 	case "TRACKED_BY":
 		return FieldCommon{FieldType: fieldType}
 	case "TRACKS":
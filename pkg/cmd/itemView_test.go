@@ -0,0 +1,16 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestItemView(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server, "item-view", "--id", "PVTI_2")
+	want := "" +
+		"Repo      Number  ID      Title          State   Author  URL\n" +
+		"acme/app  2       PVTI_2  Add dark mode  CLOSED  hubot   https://github.com/acme/app/issues/2\n"
+	assertOutput(t, want, got)
+}
@@ -2,100 +2,148 @@ package cmd
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 
-	"github.com/expr-lang/expr"
 	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
 	"github.com/tasshi-me/gh-iteration/pkg/github"
 	"github.com/tasshi-me/gh-iteration/pkg/log"
 )
 
 type ItemsEditProps struct {
 	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
 }
 
 type ItemsEditOption struct {
-	ProjectOwner   string
-	ProjectNumber  int
-	FieldName      string
-	Query          string
-	Clear          bool
-	Current        bool
-	IterationTitle string
-	DryRun         bool
+	IterationSelector
+	ProjectSelector
+	BatchOption
+
+	FieldName string
+	Query     string
+	Preset    string
+	Clear     bool
+	DryRun    bool
+	Limit     int
+	PageSize  int
+	PlanOut   string
+	Yes       bool
+	MaxItems  int
 }
 
+// exitCodeItemsFailed is the exit code of items-edit when some items failed to update.
+const exitCodeItemsFailed = 2
+
 func NewItemsEditCmd(props *ItemsEditProps) *cobra.Command {
 	opts := new(ItemsEditOption)
 
 	// itemsEditCmd represents the items-edit command.
 	itemsEditCmd := &cobra.Command{ //nolint:exhaustruct
-		Use:   "items-edit",
+		Use:   "items-edit" + projectURLArgs,
 		Short: "Edit iteration of multiple project items",
-		Long:  `Edit iteration of multiple project items`,
-		Args:  cobra.NoArgs,
-		Run: func(_ *cobra.Command, _ []string) {
-			itemsEditRun(props, opts)
+		Long:  "Edit iteration of multiple project items\n\n" + queryFunctionsHelp(),
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, opts.Preset, &opts.ProjectSelector,
+				flags.And(
+					projectFlags(),
+					flags.Flag("query"),
+					flags.Flag("field"),
+				),
+			)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			itemsEditRun(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), props, opts)
 		},
 	}
 
 	itemsEditCmd.Flags().SortFlags = false
 	itemsEditCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
 	itemsEditCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	itemsEditCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
 	itemsEditCmd.Flags().StringVar(&opts.Query, "query", "false", "Query to filter target project items")
 	itemsEditCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
+	itemsEditCmd.Flags().StringVar(&opts.Preset, "preset", "", "Preset of the config to use for the options not given")
 	itemsEditCmd.Flags().BoolVar(&opts.Clear, "clear", false, "Clear iteration field value")
-	itemsEditCmd.Flags().BoolVar(&opts.Current, "current", false, "Set current iteration as the iteration field value")
+	addIterationSelectorFlags(itemsEditCmd, &opts.IterationSelector)
 	itemsEditCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "DryRun mode")
-	itemsEditCmd.Flags().StringVar(&opts.IterationTitle, "iteration", "", "Iteration title to set")
-	itemsEditCmd.MarkFlagsOneRequired("clear", "current", "iteration")
-	_ = itemsEditCmd.MarkFlagRequired("project")
-	_ = itemsEditCmd.MarkFlagRequired("owner")
-	_ = itemsEditCmd.MarkFlagRequired("query")
-	_ = itemsEditCmd.MarkFlagRequired("field")
+	itemsEditCmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum number of project items to scan (0 for no limit)")
+	itemsEditCmd.Flags().IntVar(&opts.PageSize, "page-size", github.DefaultItemsPageSize, "Number of project items to fetch per request")
+	addBatchFlags(itemsEditCmd, &opts.BatchOption)
+	itemsEditCmd.Flags().StringVar(&opts.PlanOut, "plan-out", "",
+		"File to write the planned changes to, for apply. Nothing is updated as in dry-run mode")
+	itemsEditCmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Edit the items without confirmation")
+	itemsEditCmd.Flags().IntVar(&opts.MaxItems, "max-items", 0, "Refuse to edit more project items than this (0 for no limit)")
+	itemsEditCmd.MarkFlagsOneRequired(append([]string{"clear"}, iterationSelectorFlags...)...)
+	itemsEditCmd.MarkFlagsMutuallyExclusive(append([]string{"clear"}, iterationSelectorFlags...)...)
 
 	return itemsEditCmd
 }
 
 //nolint:funlen,gocognit,cyclop,gocyclo
-func itemsEditRun(props *ItemsEditProps, opts *ItemsEditOption) {
-	program, err := expr.Compile(opts.Query, expr.AsBool())
+func itemsEditRun(in io.Reader, out io.Writer, errOut io.Writer, props *ItemsEditProps, opts *ItemsEditOption) {
+	client, err := props.NewClient()
 	if err != nil {
-		log.Error(fmt.Errorf("failed to compile input query: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
 
-	log.Debug("Retrieve owner by login name")
-	projectOwner, err := github.FetchOwnerByLogin(opts.ProjectOwner)
+	err = opts.BatchOption.validate()
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve owner by owner login: %w", err))
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Owner: " + projectOwner.Login)
+	if len(opts.PlanOut) > 0 {
+		opts.DryRun = true
+	}
 
-	log.Debug("Retrieve project by owner and project number")
-	project, err := github.FetchProjectByNumber(opts.ProjectNumber, projectOwner.ID)
+	program, err := compileQuery(opts.Query)
 	if err != nil {
-		log.Error(fmt.Errorf("failed to retrieve a project by project number: %w", err))
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	date, err := resolveDate(opts.Date)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
 		os.Exit(1)
 	}
-	log.Debug("Project ID: " + project.ID)
 
 	log.Debug("Retrieve an iteration field by field name and project")
-	iterationField, err := github.FetchIterationFieldByName(project.ID, opts.FieldName)
+	iterationField, err := client.FetchIterationFieldByName(project.ID, opts.FieldName)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err))
 		os.Exit(1)
 	}
 
+	var iteration *github.ProjectV2IterationFieldIteration
+	if opts.isSet() {
+		iteration, err = opts.resolve(iterationField)
+		if err != nil {
+			log.Error(fmt.Errorf("failed to resolve the iteration: %w", err))
+			os.Exit(1)
+		}
+	}
+
 	log.Debug("Retrieve project items")
-	githubItems, err := github.FetchProjectItems(project.ID)
+	githubItems, err := client.FetchProjectItems(project.ID, opts.PageSize, opts.Limit)
 	if err != nil {
 		log.Error(fmt.Errorf("failed to retrieve a project items by item id: %w", err))
 		os.Exit(1)
 	}
+	log.Debug(fmt.Sprintf("Scanned items: %d", len(*githubItems)))
 
 	items := make([]ProjectItem, 0, len(*githubItems))
 	for _, githubItem := range *githubItems {
@@ -103,117 +151,232 @@ func itemsEditRun(props *ItemsEditProps, opts *ItemsEditOption) {
 		items = append(items, item)
 	}
 
-	for _, item := range items {
-		log.Debug("Item name: " + item.Title)
-
-		output, err := expr.Run(program, map[string]any{
-			"Item": item,
-		})
-		if err != nil {
-			log.Error(fmt.Errorf("failed run query: %w", err))
-			os.Exit(1)
-		}
-		pass, ok := output.(bool)
-		if !ok {
-			log.Error(errors.New("the result of query is not bool value. Please update the query"))
-			os.Exit(1)
-		}
-
-		log.Debug(fmt.Sprintf("query result: %t", pass))
-
-		if !pass {
-			continue
-		}
+	log.Debug("Retrieve project fields")
+	fields, err := client.FetchProjectFields(project.ID)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve project fields: %w", err))
+		os.Exit(1)
+	}
 
-		skipped := false
+	targets, err := filterItems(program, items, *fields, newQueryContext(client, project.ID, date))
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
 
-		iterationIDFromCurrentItem := ""
-		if item.Fields[opts.FieldName] != nil {
-			iterationFromCurrentItem, ok := item.Fields[opts.FieldName].(FieldIteration)
-			if ok {
-				iterationIDFromCurrentItem = iterationFromCurrentItem.IterationID
-			}
+	// planItem plans the edit of the item once, for the confirmation and the batches alike.
+	planItem := func(item ProjectItem) itemPlan {
+		previous := itemIteration(item, opts.FieldName)
+		result := itemsEditResult{
+			ID:                     item.ID,
+			Title:                  item.Title,
+			PreviousIterationID:    previous.IterationID,
+			PreviousIterationTitle: previous.Title,
+			NewIterationID:         "",
+			NewIterationTitle:      "",
+			Skipped:                false,
+			DryRun:                 opts.DryRun,
+			Error:                  "",
+			err:                    nil,
+			change:                 nil,
 		}
 
+		newIterationID := ""
 		switch {
-		case opts.Current:
+		case iteration != nil:
 			{
-				log.Debug("Update iteration field to current sprint")
-				currentIteration := iterationField.Configuration.Iterations[0]
-				log.Debug("current sprint: " + currentIteration.Title)
-
-				if iterationIDFromCurrentItem == currentIteration.ID {
-					log.Debug("No need to update. Skip.")
-					skipped = true
-				} else if !opts.DryRun {
-					_, err = github.UpdateIterationField(project.ID, iterationField.ID, item.ID, currentIteration.ID)
-				}
-			}
-		case len(opts.IterationTitle) > 0:
-			{
-				log.Debug("Update iteration field to specified sprint:" + opts.IterationTitle)
-				var iteration github.ProjectV2IterationFieldIteration
-				for _, iter := range iterationField.Configuration.Iterations {
-					if iter.Title == opts.IterationTitle {
-						iteration = iter
-					}
-				}
-				for _, iter := range iterationField.Configuration.CompletedIterations {
-					if iter.Title == opts.IterationTitle {
-						iteration = iter
-					}
-				}
-				if len(iteration.ID) > 0 {
-					if iterationIDFromCurrentItem == iteration.ID {
-						log.Debug("No need to update. Skip.")
-						skipped = true
-					} else if !opts.DryRun {
-						_, err = github.UpdateIterationField(project.ID, iterationField.ID, item.ID, iteration.ID)
-					}
-				} else {
-					err = fmt.Errorf("cannot find specified iteration: %s", opts.IterationTitle)
-				}
+				log.Debug("Update iteration field to sprint: " + iteration.Title)
+				newIterationID = iteration.ID
+				result.NewIterationTitle = iteration.Title
 			}
 		case opts.Clear:
 			{
 				log.Debug("Clear iteration field")
-				if len(iterationIDFromCurrentItem) == 0 {
-					log.Debug("No need to update. Skip.")
-					skipped = true
-				} else if !opts.DryRun {
-					_, err = github.ClearIterationField(project.ID, iterationField.ID, item.ID)
-				}
 			}
 		}
+		result.NewIterationID = newIterationID
+		if previous.IterationID == newIterationID {
+			log.Debug("No need to update. Skip.")
+			result.Skipped = true
+			return itemPlan{result: result, update: nil}
+		}
 
-		if err != nil {
-			log.Error(fmt.Errorf("failed to update an iteration field: %w", err))
-			os.Exit(1)
+		result.change = &itemsEditChange{ItemID: item.ID, FromIterationID: previous.IterationID, ToIterationID: newIterationID}
+		if opts.DryRun {
+			return itemPlan{result: result, update: nil}
+		}
+		return itemPlan{result: result, update: &github.IterationFieldUpdate{ItemID: item.ID, IterationID: newIterationID}}
+	}
+
+	plans := make([]itemPlan, 0, len(targets))
+	var edits []itemsEditResult
+	for _, item := range targets {
+		plan := planItem(item)
+		plans = append(plans, plan)
+		if !plan.result.Skipped {
+			edits = append(edits, plan.result)
 		}
+	}
 
-		result := struct {
-			ID      string `json:"id"`
-			Title   string `json:"title"`
-			Skipped bool   `json:"skipped"`
-			DryRun  bool   `json:"dryRun"`
-		}{ID: item.ID, Title: item.Title, Skipped: skipped, DryRun: opts.DryRun}
+	if !opts.DryRun {
+		if opts.MaxItems > 0 && len(edits) > opts.MaxItems {
+			log.Error(fmt.Errorf("%d items to edit exceed --max-items %d", len(edits), opts.MaxItems))
+			os.Exit(1)
+		}
 
-		if *props.OutputFormatJSON {
-			bytes, err := json.MarshalIndent(result, "", "  ")
+		if len(edits) > 0 && !opts.Yes && isTerminal(in) {
+			_, _ = fmt.Fprint(errOut, formatItemsEditPreview(edits))
+			confirmed, err := confirm(in, errOut, fmt.Sprintf("Edit %d items?", len(edits)))
 			if err != nil {
-				log.Error(fmt.Errorf("failed to marshal result: %w", err))
+				log.Error(err)
 				os.Exit(1)
 			}
-			_, _ = fmt.Fprintln(os.Stdout, string(bytes))
-		} else {
-			switch {
-			case skipped:
-				_, _ = fmt.Fprintf(os.Stdout, "%s %s => No need to update. Skipped.\n", item.ID, item.Title)
-			case opts.DryRun:
-				_, _ = fmt.Fprintf(os.Stdout, "%s %s => DryRun.\n", item.ID, item.Title)
-			default:
-				_, _ = fmt.Fprintf(os.Stdout, "%s %s => Updated.\n", item.ID, item.Title)
+			if !confirmed {
+				_, _ = fmt.Fprintln(errOut, "Canceled.")
+				return
 			}
 		}
 	}
+
+	var journal *journalWriter
+	if !opts.DryRun {
+		journal, err = openJournal(opts.Journal)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	changes, summary, stopped := editItems(client, project.ID, iterationField.ID, plans, &opts.BatchOption, journal,
+		func(result itemsEditResult) {
+			printItemsEditResult(out, *props.OutputFormatJSON, result)
+		})
+	if journal != nil {
+		err := journal.Close()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+	if stopped {
+		os.Exit(1)
+	}
+
+	summary.Scanned = len(items)
+	if !*props.OutputFormatJSON {
+		_, _ = fmt.Fprintf(out, "%d items scanned.\n", summary.Scanned)
+	}
+	if len(opts.PlanOut) > 0 {
+		plan := itemsEditPlan{ProjectID: project.ID, FieldID: iterationField.ID, Changes: changes}
+		err := writePlan(opts.PlanOut, plan)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		if !*props.OutputFormatJSON {
+			_, _ = fmt.Fprintf(out, "%d changes planned in %s.\n", len(plan.Changes), opts.PlanOut)
+		}
+	}
+	// The JSON output always ends with the summary, which has the number of items scanned.
+	if opts.ContinueOnError || *props.OutputFormatJSON {
+		printItemsEditSummary(out, *props.OutputFormatJSON, summary, opts.DryRun)
+	}
+	if summary.Failed > 0 {
+		os.Exit(exitCodeItemsFailed)
+	}
+}
+
+func printItemsEditResult(out io.Writer, outputFormatJSON bool, result itemsEditResult) {
+	if outputFormatJSON {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal result: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+	} else {
+		change := formatIterationChange(result.PreviousIterationTitle, result.NewIterationTitle)
+		switch {
+		case len(result.Error) > 0:
+			_, _ = fmt.Fprintf(out, "%s %s %s => Failed. %s\n", result.ID, result.Title, change, result.Error)
+		case result.Skipped:
+			_, _ = fmt.Fprintf(out, "%s %s %s => No need to update. Skipped.\n", result.ID, result.Title, change)
+		case result.DryRun:
+			_, _ = fmt.Fprintf(out, "%s %s %s => DryRun.\n", result.ID, result.Title, change)
+		default:
+			_, _ = fmt.Fprintf(out, "%s %s %s => Updated.\n", result.ID, result.Title, change)
+		}
+	}
+}
+
+type itemsEditResult struct {
+	ID                     string `json:"id"`
+	Title                  string `json:"title"`
+	PreviousIterationID    string `json:"previousIterationId"`
+	PreviousIterationTitle string `json:"previousIterationTitle"`
+	NewIterationID         string `json:"newIterationId"`
+	NewIterationTitle      string `json:"newIterationTitle"`
+	Skipped                bool   `json:"skipped"`
+	DryRun                 bool   `json:"dryRun"`
+	Error                  string `json:"error,omitempty"`
+	err                    error
+	change                 *itemsEditChange
+}
+
+// formatItemsEditPreview formats the changes to be made for confirmation.
+func formatItemsEditPreview(changes []itemsEditResult) string {
+	idLen, titleLen, previousLen := len("ID"), len("Title"), len("Current")
+	for _, change := range changes {
+		idLen = max(idLen, len(change.ID))
+		titleLen = max(titleLen, len(change.Title))
+		previousLen = max(previousLen, len(change.PreviousIterationTitle))
+	}
+
+	var sb strings.Builder
+	format := "%-" + strconv.Itoa(idLen) + "s  %-" + strconv.Itoa(titleLen) + "s  %-" + strconv.Itoa(previousLen) + "s  %s\n"
+	sb.WriteString(fmt.Sprintf(format, "ID", "Title", "Current", "New"))
+	for _, change := range changes {
+		sb.WriteString(fmt.Sprintf(format, change.ID, change.Title, change.PreviousIterationTitle, change.NewIterationTitle))
+	}
+	return sb.String()
+}
+
+type itemsEditSummary struct {
+	Scanned int `json:"scanned"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+func (s *itemsEditSummary) add(result itemsEditResult) {
+	switch {
+	case len(result.Error) > 0:
+		s.Failed++
+	case result.Skipped:
+		s.Skipped++
+	default:
+		s.Updated++
+	}
+}
+
+func printItemsEditSummary(out io.Writer, outputFormatJSON bool, summary itemsEditSummary, dryRun bool) {
+	if outputFormatJSON {
+		bytes, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal summary: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+		return
+	}
+
+	_, _ = fmt.Fprint(out, formatItemsEditSummary(summary, dryRun))
+}
+
+func formatItemsEditSummary(summary itemsEditSummary, dryRun bool) string {
+	updated := "updated"
+	if dryRun {
+		updated = "to be updated"
+	}
+	return fmt.Sprintf("%d %s, %d skipped, %d failed.\n", summary.Updated, updated, summary.Skipped, summary.Failed)
 }
@@ -0,0 +1,254 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+const queryInProgress = `Item.Fields.Status.Name == "In progress"`
+
+func TestItemsEdit(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--current",
+		"--date", fixtureToday)
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> Sprint 3) => Updated.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> Sprint 3) => No need to update. Skipped.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> Sprint 3) => Updated.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_2", "Sprint", "sprint_2")
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_6", "Sprint", "sprint_3")
+}
+
+func TestItemsEditJSON(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", `Item.Title == "Fix login bug"`,
+		"--current", "--date", fixtureToday, "--json")
+	want := `{
+  "id": "PVTI_1",
+  "title": "Fix login bug",
+  "previousIterationId": "sprint_2",
+  "previousIterationTitle": "Sprint 2",
+  "newIterationId": "sprint_3",
+  "newIterationTitle": "Sprint 3",
+  "skipped": false,
+  "dryRun": false
+}
+{
+  "scanned": 6,
+  "updated": 1,
+  "skipped": 0,
+  "failed": 0
+}
+`
+	assertOutput(t, want, got)
+}
+
+func TestItemsEditDryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress,
+		"--iteration", "Sprint 4", "--dry-run")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> Sprint 4) => DryRun.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> Sprint 4) => DryRun.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> Sprint 4) => DryRun.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_2")
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_6", "Sprint", nil)
+}
+
+func TestItemsEditLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--clear", "--page-size", "2", "--limit", "3")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_2 Add dark mode (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n" +
+		"3 items scanned.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_3", "Sprint", nil)
+	assertFieldValue(t, server, "PVTI_5", "Sprint", "sprint_4")
+}
+
+func TestItemsEditOffset(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", `Item.Fields.Status.Name == "Todo"`,
+		"--offset", "2", "--date", fixtureToday)
+	want := "" +
+		"PVTI_4 Write release notes ((none) -> Sprint 5) => Updated.\n" +
+		"PVTI_5 Update dependencies (Sprint 4 -> Sprint 5) => Updated.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_4", "Sprint", "sprint_5")
+	assertFieldValue(t, server, "PVTI_5", "Sprint", "sprint_5")
+}
+
+func TestItemsEditConcurrency(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	server.LimitRate(3)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--iteration", "Sprint 4", "--concurrency", "4")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> Sprint 4) => Updated.\n" +
+		"PVTI_2 Add dark mode (Sprint 2 -> Sprint 4) => Updated.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> Sprint 4) => Updated.\n" +
+		"PVTI_4 Write release notes ((none) -> Sprint 4) => Updated.\n" +
+		"PVTI_5 Update dependencies (Sprint 4 -> Sprint 4) => No need to update. Skipped.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> Sprint 4) => Updated.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+
+	for _, itemID := range []string{"PVTI_1", "PVTI_2", "PVTI_3", "PVTI_4", "PVTI_5", "PVTI_6"} {
+		assertFieldValue(t, server, itemID, "Sprint", "sprint_4")
+	}
+}
+
+func TestItemsEditBatch(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--clear", "--batch-size", "3", "--concurrency", "2")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_2 Add dark mode (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n" +
+		"PVTI_4 Write release notes ((none) -> (none)) => No need to update. Skipped.\n" +
+		"PVTI_5 Update dependencies (Sprint 4 -> (none)) => Updated.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> (none)) => No need to update. Skipped.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+
+	batches := 0
+	for _, operation := range server.Operations() {
+		if operation == "UpdateIterationFields" {
+			batches++
+		}
+	}
+	if batches != 2 {
+		t.Errorf("want 2 batches, got %d", batches)
+	}
+	for _, itemID := range []string{"PVTI_1", "PVTI_2", "PVTI_3", "PVTI_5"} {
+		assertFieldValue(t, server, itemID, "Sprint", nil)
+	}
+}
+
+func TestItemsEditContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) { server.FailItem("PVTI_2") },
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--clear", "--continue-on-error")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_2 Add dark mode (Sprint 2 -> (none)) => Failed. failed to update the iteration field: " +
+		"GraphQL: something went wrong while updating the item 'PVTI_2' (m0)\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n" +
+		"PVTI_4 Write release notes ((none) -> (none)) => No need to update. Skipped.\n" +
+		"PVTI_5 Update dependencies (Sprint 4 -> (none)) => Updated.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> (none)) => No need to update. Skipped.\n" +
+		"6 items scanned.\n" +
+		"3 updated, 2 skipped, 1 failed.\n"
+	assertOutput(t, want, got)
+	if code != 2 {
+		t.Errorf("want exit code 2, got %d", code)
+	}
+}
+
+func TestItemsEditStopOnError(t *testing.T) {
+	t.Parallel()
+
+	// The batch updates PVTI_1, PVTI_3 and PVTI_5 along with the failed PVTI_2.
+	journal := subprocessTempFile(t, "journal.jsonl")
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) { server.FailItem("PVTI_2") },
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--clear", "--batch-size", "6", "--journal", journal)
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_2 Add dark mode (Sprint 2 -> (none)) => Failed. failed to update the iteration field: " +
+		"GraphQL: something went wrong while updating the item 'PVTI_2' (m1)\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n" +
+		"PVTI_4 Write release notes ((none) -> (none)) => No need to update. Skipped.\n" +
+		"PVTI_5 Update dependencies (Sprint 4 -> (none)) => Updated.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> (none)) => No need to update. Skipped.\n"
+	assertOutput(t, want, got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+
+	bytes, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var itemIDs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(bytes)), "\n") {
+		var entry struct {
+			ItemID string `json:"itemId"`
+		}
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		itemIDs = append(itemIDs, entry.ItemID)
+	}
+	if !slices.Equal(itemIDs, []string{"PVTI_1", "PVTI_3", "PVTI_5"}) {
+		t.Errorf("wrong journaled items want: [PVTI_1 PVTI_3 PVTI_5], got %v", itemIDs)
+	}
+}
+
+func TestItemsEditMaxItems(t *testing.T) {
+	t.Parallel()
+
+	got, code := runCmdInSubprocess(t, func(*githubtest.Server) {},
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", "true",
+		"--clear", "--max-items", "3")
+	assertOutput(t, "", got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+
+	server := newServer(t)
+	got = runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress,
+		"--clear", "--max-items", "3", "--yes")
+	want := "" +
+		"PVTI_1 Fix login bug (Sprint 2 -> (none)) => Updated.\n" +
+		"PVTI_3 Refactor API client (Sprint 3 -> (none)) => Updated.\n" +
+		"PVTI_6 Investigate flaky test ((none) -> (none)) => No need to update. Skipped.\n" +
+		"6 items scanned.\n"
+	assertOutput(t, want, got)
+}
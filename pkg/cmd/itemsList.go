@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type ItemsListProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type ItemsListOption struct {
+	ProjectSelector
+
+	Query    string
+	Preset   string
+	Columns  []string
+	Date     string
+	Limit    int
+	PageSize int
+}
+
+// itemColumns are the columns of project item properties, with their headers.
+// The other columns show the values of the fields with the same names.
+//
+//nolint:gochecknoglobals
+var itemColumns = map[string]string{
+	"id":         "ID",
+	"title":      "Title",
+	"type":       "Type",
+	"repository": "Repo",
+	"number":     "Number",
+	"url":        "URL",
+	"state":      "State",
+	"reason":     "Reason",
+	"draft":      "Draft",
+	"merged":     "Merged",
+	"author":     "Author",
+	"created":    "Created",
+	"updated":    "Updated",
+	"closed":     "Closed",
+	"archived":   "Archived",
+}
+
+func NewItemsListCmd(props *ItemsListProps) *cobra.Command {
+	opts := new(ItemsListOption)
+
+	// itemsListCmd represents the items-list command.
+	itemsListCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "items-list" + projectURLArgs,
+		Short: "List project items matching a query",
+		Long: `List project items matching a query, in the same syntax as items-edit.
+Columns are id, title, type, repository, number, url, state, reason, draft, merged, author, created, updated, closed,
+archived, or the name of a field to show its value.
+
+` + queryFunctionsHelp(),
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, opts.Preset, &opts.ProjectSelector,
+				flags.And(
+					projectFlags(),
+				),
+			)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			itemsListRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	itemsListCmd.Flags().SortFlags = false
+	itemsListCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	itemsListCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	itemsListCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	itemsListCmd.Flags().StringVar(&opts.Query, "query", "true", "Query to filter project items")
+	itemsListCmd.Flags().StringVar(&opts.Preset, "preset", "", "Preset of the config to use for the options not given")
+	itemsListCmd.Flags().StringSliceVar(&opts.Columns, "columns", []string{"id", "title", "type"}, "Columns to show")
+	itemsListCmd.Flags().StringVar(&opts.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration in queries (default: today in the local time zone)")
+	itemsListCmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum number of project items to scan (0 for no limit)")
+	itemsListCmd.Flags().IntVar(&opts.PageSize, "page-size", github.DefaultItemsPageSize, "Number of project items to fetch per request")
+
+	return itemsListCmd
+}
+
+func itemsListRun(out io.Writer, props *ItemsListProps, opts *ItemsListOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	program, err := compileQuery(opts.Query)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	date, err := resolveDate(opts.Date)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	log.Debug("Retrieve project items")
+	githubItems, err := client.FetchProjectItems(project.ID, opts.PageSize, opts.Limit)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve project items: %w", err))
+		os.Exit(1)
+	}
+	items := make([]ProjectItem, 0, len(*githubItems))
+	for _, githubItem := range *githubItems {
+		items = append(items, ConvertGitHubProjectItem(&githubItem))
+	}
+
+	log.Debug("Retrieve project fields")
+	fields, err := client.FetchProjectFields(project.ID)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve project fields: %w", err))
+		os.Exit(1)
+	}
+
+	matched, err := filterItems(program, items, *fields, newQueryContext(client, project.ID, date))
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	if *props.OutputFormatJSON {
+		rows := make([]map[string]any, 0, len(matched))
+		for _, item := range matched {
+			row := map[string]any{}
+			for _, column := range opts.Columns {
+				row[column] = itemColumnValue(item, column)
+			}
+			rows = append(rows, row)
+		}
+		bytes, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal items: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+	} else {
+		_, _ = fmt.Fprint(out, formatItemsPlain(matched, opts.Columns))
+	}
+}
+
+// itemColumnValue returns the value of the column of the project item.
+func itemColumnValue(item ProjectItem, column string) any {
+	switch column {
+	case "id":
+		return item.ID
+	case "title":
+		return item.Title
+	case "type":
+		return item.Type
+	case "repository":
+		return item.Repository
+	case "number":
+		return item.Number
+	case "url":
+		return item.URL
+	case "state":
+		return item.State
+	case "reason":
+		return item.StateReason
+	case "draft":
+		return item.IsDraft
+	case "merged":
+		return item.Merged
+	case "author":
+		return item.Author
+	case "created":
+		return item.CreatedAt
+	case "updated":
+		return item.UpdatedAt
+	case "closed":
+		return item.ClosedAt
+	case "archived":
+		return item.IsArchived
+	default:
+		return item.Fields[column]
+	}
+}
+
+// formatItemColumnPlain formats the value of the column of the project item for the plain output.
+//
+//nolint:cyclop
+func formatItemColumnPlain(item ProjectItem, column string) string {
+	switch value := itemColumnValue(item, column).(type) {
+	case string:
+		return value
+	case int:
+		if value == 0 {
+			return ""
+		}
+		return strconv.Itoa(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case FieldIteration:
+		return value.Title
+	case FieldSingleSelect:
+		return value.Name
+	case FieldNumber:
+		return strconv.FormatFloat(float64(value.Number), 'f', -1, 64)
+	case FieldDate:
+		return value.Date
+	case FieldText:
+		return value.Text
+	case FieldLabels:
+		return strings.Join(value.Names, ", ")
+	case FieldUsers:
+		return strings.Join(value.Logins, ", ")
+	case FieldMilestone:
+		return value.Title
+	case FieldReviewers:
+		return strings.Join(value.Logins, ", ")
+	case FieldRepository:
+		return value.NameWithOwner
+	case FieldPullRequests:
+		numbers := make([]string, 0, len(value.Numbers))
+		for _, number := range value.Numbers {
+			numbers = append(numbers, "#"+strconv.Itoa(number))
+		}
+		return strings.Join(numbers, ", ")
+	default:
+		return ""
+	}
+}
+
+func formatItemsPlain(items []ProjectItem, columns []string) string {
+	return formatItemsTable(items, columns) + fmt.Sprintf("%d items matched.\n", len(items))
+}
+
+// formatItemsTable formats the columns of the project items as a table.
+func formatItemsTable(items []ProjectItem, columns []string) string {
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header, ok := itemColumns[column]
+		if !ok {
+			header = column
+		}
+		headers = append(headers, header)
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, formatItemColumnPlain(item, column))
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(columns))
+	for i, header := range headers {
+		widths[i] = len(header)
+		for _, row := range rows {
+			widths[i] = max(widths[i], len(row[i]))
+		}
+	}
+
+	var sb strings.Builder
+	for _, row := range append([][]string{headers}, rows...) {
+		cells := make([]string, 0, len(row))
+		for i, cell := range row {
+			cells = append(cells, fmt.Sprintf("%-"+strconv.Itoa(widths[i])+"s", cell))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+	}
+	return sb.String()
+}
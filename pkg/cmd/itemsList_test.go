@@ -0,0 +1,162 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestItemsList(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server, "items-list", "--owner", "acme", "--project", "1", "--query", queryInProgress)
+	want := "" +
+		"ID      Title                   Type\n" +
+		"PVTI_1  Fix login bug           ISSUE\n" +
+		"PVTI_3  Refactor API client     PULL_REQUEST\n" +
+		"PVTI_6  Investigate flaky test  ISSUE\n" +
+		"3 items matched.\n"
+	assertOutput(t, want, got)
+}
+
+func TestItemsListColumns(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-list", "--owner", "acme", "--project", "1", "--columns", "id,Status,Sprint,number", "--limit", "3")
+	want := "" +
+		"ID      Status       Sprint    Number\n" +
+		"PVTI_1  In progress  Sprint 2  1\n" +
+		"PVTI_2  Done         Sprint 2  2\n" +
+		"PVTI_3  In progress  Sprint 3  3\n" +
+		"3 items matched.\n"
+	assertOutput(t, want, got)
+}
+
+func TestItemsListJSON(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-list", "--owner", "acme", "--project", "1", "--query", `Item.ID == "PVTI_1"`, "--columns", "id,Sprint", "--json")
+	want := `[
+  {
+    "Sprint": {
+      "fieldType": "ITERATION",
+      "iterationId": "sprint_2",
+      "startDate": "2026-09-21",
+      "duration": 14,
+      "title": "Sprint 2",
+      "titleHtml": "Sprint 2"
+    },
+    "id": "PVTI_1"
+  }
+]
+`
+	assertOutput(t, want, got)
+}
+
+func TestItemsListFieldValues(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-list", "--owner", "acme", "--project", "1", "--query", `"bug" in Item.Fields.Labels.Names || Item.Type == "PULL_REQUEST"`,
+		"--columns", "id,Due,Notes,Labels,Assignees,Milestone,Reviewers,Repository,Linked pull requests")
+	want := "" +
+		"ID      Due         Notes                Labels       Assignees  Milestone  Reviewers           Repository  Linked pull requests\n" +
+		"PVTI_1  2026-10-20  Reported by support  bug, auth    octocat    v1.0                           acme/app    #3\n" +
+		"PVTI_3                                   refactoring  hubot                 octocat, acme/core  acme/app\n" +
+		"2 items matched.\n"
+	assertOutput(t, want, got)
+}
+
+func TestItemsListState(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"items-list", "--owner", "acme", "--project", "1", "--query", `Item.State == "CLOSED" || Item.IsDraft || Item.Author == "octocat"`,
+		"--columns", "id,state,reason,draft,author,closed")
+	want := "" +
+		"ID      State   Reason     Draft  Author   Closed\n" +
+		"PVTI_1  OPEN               false  octocat\n" +
+		"PVTI_2  CLOSED  COMPLETED  false  hubot    2026-10-02T17:00:00Z\n" +
+		"PVTI_3  OPEN               true   hubot\n" +
+		"PVTI_4                     false  octocat\n" +
+		"4 items matched.\n"
+	assertOutput(t, want, got)
+}
+
+func TestItemsListQueryFunctions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "current iteration", query: `inIteration("Sprint", "current")`, want: "PVTI_3"},
+		{name: "previous iteration", query: `inIteration("Sprint", "previous")`, want: "PVTI_1, PVTI_2"},
+		{name: "iteration by title", query: `inIteration("Sprint", "Sprint 4")`, want: "PVTI_5"},
+		{name: "label ignoring case", query: `hasLabel("BUG")`, want: "PVTI_1"},
+		{name: "assignee", query: `assignedTo("hubot")`, want: "PVTI_3"},
+		{name: "empty field", query: `fieldEmpty("Sprint")`, want: "PVTI_4, PVTI_6"},
+		{name: "days since", query: `Item.UpdatedAt != "" && daysSince(Item.UpdatedAt) <= 2`, want: "PVTI_1, PVTI_3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server,
+				"items-list", "--owner", "acme", "--project", "1", "--query", tt.query, "--date", fixtureToday, "--columns", "id", "--json")
+			assertOutput(t, tt.want, strings.Join(jsonItemIDs(t, got), ", "))
+		})
+	}
+}
+
+func TestItemsListQueryFunctionsWithoutValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		date  string
+		want  string
+	}{
+		{name: "after the last iteration", query: `inIteration("Sprint", "current")`, date: "2027-03-01", want: ""},
+		{
+			name: "no next iteration", query: `!inIteration("Sprint", "next")`, date: "2027-03-01",
+			want: "PVTI_1, PVTI_2, PVTI_3, PVTI_4, PVTI_5, PVTI_6",
+		},
+		{name: "empty date", query: `daysSince(Item.UpdatedAt) <= 2`, date: fixtureToday, want: "PVTI_1, PVTI_3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server,
+				"items-list", "--owner", "acme", "--project", "1", "--query", tt.query, "--date", tt.date, "--columns", "id", "--json")
+			assertOutput(t, tt.want, strings.Join(jsonItemIDs(t, got), ", "))
+		})
+	}
+}
+
+func jsonItemIDs(t *testing.T, output string) []string {
+	t.Helper()
+
+	var rows []map[string]string
+	err := json.Unmarshal([]byte(output), &rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row["id"])
+	}
+	return ids
+}
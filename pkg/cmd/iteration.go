@@ -0,0 +1,451 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// allIterations returns the completed and the active iterations of the field in the order of their start dates.
+func allIterations(field *github.ProjectV2IterationField) []github.ProjectV2IterationFieldIteration {
+	iterations := slices.Concat(field.Configuration.CompletedIterations, field.Configuration.Iterations)
+	slices.SortStableFunc(iterations, func(a, b github.ProjectV2IterationFieldIteration) int {
+		return strings.Compare(a.StartDate, b.StartDate)
+	})
+	return iterations
+}
+
+// iterationEndDate returns the day after the last day of the iteration.
+func iterationEndDate(iteration github.ProjectV2IterationFieldIteration) (time.Time, error) {
+	startDate, err := time.Parse(time.DateOnly, iteration.StartDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start date of iteration %s: %w", iteration.Title, err)
+	}
+	return startDate.AddDate(0, 0, iteration.Duration), nil
+}
+
+// findIterationByTitle returns the completed or active iteration of the field with the title.
+// It fails if more than one iteration has the title.
+func findIterationByTitle(field *github.ProjectV2IterationField, title string) (*github.ProjectV2IterationFieldIteration, error) {
+	var found []github.ProjectV2IterationFieldIteration
+	for _, iteration := range allIterations(field) {
+		if iteration.Title == title {
+			found = append(found, iteration)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("cannot find specified iteration: %s", title)
+	case 1:
+		return &found[0], nil
+	default:
+		candidates := make([]string, 0, len(found))
+		for _, iteration := range found {
+			candidates = append(candidates, fmt.Sprintf("%s (ID: %s, StartDate: %s)", iteration.Title, iteration.ID, iteration.StartDate))
+		}
+		return nil, fmt.Errorf("%d iterations match the title %s, specify one by ID or date: %s",
+			len(found), title, strings.Join(candidates, ", "))
+	}
+}
+
+// findIterationByID returns the completed or active iteration of the field with the ID.
+func findIterationByID(field *github.ProjectV2IterationField, id string) (*github.ProjectV2IterationFieldIteration, error) {
+	for _, iteration := range allIterations(field) {
+		if iteration.ID == id {
+			return &iteration, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find specified iteration ID: %s", id)
+}
+
+// itemIteration returns the value of the iteration field of the project item, or the zero value if it is empty.
+func itemIteration(item ProjectItem, fieldName string) FieldIteration {
+	value, _ := item.Fields[fieldName].(FieldIteration)
+	return value
+}
+
+// formatIterationChange formats the iteration field value of a project item before and after an edit.
+func formatIterationChange(previousTitle string, newTitle string) string {
+	none := func(title string) string {
+		if len(title) == 0 {
+			return "(none)"
+		}
+		return title
+	}
+	return fmt.Sprintf("(%s -> %s)", none(previousTitle), none(newTitle))
+}
+
+// iterationRewrite is an iteration of a field before and after the iterations of the field are rewritten.
+// Previous is nil for a new iteration, and New is nil for a deleted one.
+type iterationRewrite struct {
+	Previous *github.ProjectV2IterationFieldIteration
+	New      *github.ProjectV2Iteration
+}
+
+// keepIterations returns the rewrites keeping the iterations as they are.
+func keepIterations(iterations []github.ProjectV2IterationFieldIteration) []iterationRewrite {
+	rewrites := make([]iterationRewrite, 0, len(iterations))
+	for _, iteration := range iterations {
+		rewrites = append(rewrites, iterationRewrite{
+			Previous: &iteration,
+			New: &github.ProjectV2Iteration{
+				Duration:  iteration.Duration,
+				StartDate: iteration.StartDate,
+				Title:     iteration.Title,
+			},
+		})
+	}
+	return rewrites
+}
+
+// formatIterationRewrites formats the rewrites of the iterations of the field for a preview.
+func formatIterationRewrites(field *github.ProjectV2IterationField, rewrites []iterationRewrite) string {
+	format := func(title string, startDate string, duration int) string {
+		return fmt.Sprintf("%s (%s, %d days)", title, startDate, duration)
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "All the iterations of field %s are rewritten:\n", field.Name)
+	for _, rewrite := range rewrites {
+		switch {
+		case rewrite.Previous == nil:
+			_, _ = fmt.Fprintf(&b, "  + %s\n", format(rewrite.New.Title, rewrite.New.StartDate, rewrite.New.Duration))
+		case rewrite.New == nil:
+			_, _ = fmt.Fprintf(&b, "  - %s\n", format(rewrite.Previous.Title, rewrite.Previous.StartDate, rewrite.Previous.Duration))
+		default:
+			previous := format(rewrite.Previous.Title, rewrite.Previous.StartDate, rewrite.Previous.Duration)
+			next := format(rewrite.New.Title, rewrite.New.StartDate, rewrite.New.Duration)
+			if previous == next {
+				_, _ = fmt.Fprintf(&b, "    %s\n", previous)
+			} else {
+				_, _ = fmt.Fprintf(&b, "  ~ %s -> %s\n", previous, next)
+			}
+		}
+	}
+	b.WriteString("The iterations get new IDs, and the project items in the kept ones are set to them again. " +
+		"Project items in a deleted iteration lose their value.\n")
+	return b.String()
+}
+
+// confirmIterationRewrites shows the rewrites of the iterations of the field and asks to go on.
+// Unless yes is set, it refuses to go on without asking if the input is not a terminal.
+func confirmIterationRewrites(
+	in io.Reader, errOut io.Writer, field *github.ProjectV2IterationField, rewrites []iterationRewrite, yes bool,
+) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !isTerminal(in) {
+		return false, fmt.Errorf("cannot confirm to rewrite the iterations of field %s: set --yes to rewrite them without confirmation",
+			field.Name)
+	}
+	_, _ = fmt.Fprint(errOut, formatIterationRewrites(field, rewrites))
+	return confirm(in, errOut, fmt.Sprintf("Rewrite the iterations of field %s?", field.Name))
+}
+
+// updateIterations rewrites the iterations of the field, including the completed ones.
+// The API takes the iterations without their IDs and gives them new IDs, which clears the field values
+// of the project items, so it sets the values of the items in the kept iterations again.
+func updateIterations(
+	client *github.Client, projectID string, field *github.ProjectV2IterationField, rewrites []iterationRewrite,
+) (*github.ProjectV2IterationField, error) {
+	log.Debug("Retrieve project items")
+	githubItems, err := client.FetchProjectItems(projectID, github.DefaultItemsPageSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve project items: %w", err)
+	}
+	items := make([]ProjectItem, 0, len(*githubItems))
+	for _, githubItem := range *githubItems {
+		items = append(items, ConvertGitHubProjectItem(&githubItem))
+	}
+
+	iterations := make([]github.ProjectV2Iteration, 0, len(rewrites))
+	for _, rewrite := range rewrites {
+		if rewrite.New != nil {
+			iterations = append(iterations, *rewrite.New)
+		}
+	}
+
+	startDate := field.Configuration.StartDate
+	if len(iterations) > 0 {
+		startDate = slices.MinFunc(iterations, func(a, b github.ProjectV2Iteration) int {
+			return strings.Compare(a.StartDate, b.StartDate)
+		}).StartDate
+	}
+
+	updated, err := client.UpdateIterationFieldConfiguration(field.ID, github.ProjectV2IterationFieldConfigurationInput{
+		Duration:   field.Configuration.Duration,
+		Iterations: iterations,
+		StartDate:  startDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update iterations: %w", err)
+	}
+
+	err = restoreIterationValues(client, projectID, field, updated, rewrites, items)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// restoreIterationValues sets the field values of the project items in the kept iterations
+// to the iterations of the updated field with the same start dates and titles.
+// The items in the deleted iterations lose their values.
+func restoreIterationValues(
+	client *github.Client, projectID string, field *github.ProjectV2IterationField, updated *github.ProjectV2IterationField,
+	rewrites []iterationRewrite, items []ProjectItem,
+) error {
+	renewed := map[string]github.ProjectV2IterationFieldIteration{}
+	for _, rewrite := range rewrites {
+		if rewrite.Previous == nil || rewrite.New == nil {
+			continue
+		}
+		for _, iteration := range allIterations(updated) {
+			if iteration.StartDate == rewrite.New.StartDate && iteration.Title == rewrite.New.Title {
+				renewed[rewrite.Previous.ID] = iteration
+			}
+		}
+	}
+
+	var updates []github.IterationFieldUpdate
+	var titles []string
+	for _, item := range items {
+		iteration, ok := renewed[itemIteration(item, field.Name).IterationID]
+		if !ok || iteration.ID == itemIteration(item, field.Name).IterationID {
+			continue
+		}
+		updates = append(updates, github.IterationFieldUpdate{ItemID: item.ID, IterationID: iteration.ID})
+		titles = append(titles, iteration.Title)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	log.Debug(fmt.Sprintf("Restore the iteration field values of %d project items", len(updates)))
+	errs, err := client.UpdateIterationFields(projectID, updated.ID, updates, github.MaxBatchSize)
+	var lost []string
+	for i, update := range updates {
+		if err != nil || errs[i] != nil {
+			lost = append(lost, fmt.Sprintf("%s (%s)", update.ItemID, titles[i]))
+		}
+	}
+	if len(lost) > 0 {
+		return fmt.Errorf("failed to restore the %s values of %d project items, set them again: %s",
+			field.Name, len(lost), strings.Join(lost, ", "))
+	}
+	return nil
+}
+
+// printIterations prints the iterations in the output format of the list command.
+func printIterations(out io.Writer, outputFormatJSON bool, iterations []github.ProjectV2IterationFieldIteration) {
+	if outputFormatJSON {
+		s, err := formatIterationsJSON(iterations)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprint(out, s)
+	} else {
+		s := formatIterationsPlain(iterations)
+		_, _ = fmt.Fprint(out, s)
+	}
+}
+
+// today returns the current date in the local time zone, which is set by the TZ environment variable.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// resolveDate parses the date (YYYY-MM-DD) used to resolve the current iteration, or returns today if it is empty.
+// Dates are compared as calendar dates, so they are kept in UTC regardless of the time zone.
+func resolveDate(date string) (time.Time, error) {
+	if len(date) == 0 {
+		return today(), nil
+	}
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %w", err)
+	}
+	return d, nil
+}
+
+// currentIteration returns the iteration whose period contains the date.
+func currentIteration(field *github.ProjectV2IterationField, date time.Time) (*github.ProjectV2IterationFieldIteration, error) {
+	iterations := allIterations(field)
+	if len(iterations) == 0 {
+		return nil, fmt.Errorf("iteration field %s has no iterations", field.Name)
+	}
+
+	var before *github.ProjectV2IterationFieldIteration
+	for _, iteration := range iterations {
+		startDate, err := time.Parse(time.DateOnly, iteration.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date of iteration %s: %w", iteration.Title, err)
+		}
+		if date.Before(startDate) {
+			if before == nil {
+				return nil, fmt.Errorf("no iteration on %s: the first iteration %s starts on %s",
+					date.Format(time.DateOnly), iteration.Title, iteration.StartDate)
+			}
+			return nil, fmt.Errorf("no iteration on %s: it falls in the gap between %s and %s",
+				date.Format(time.DateOnly), before.Title, iteration.Title)
+		}
+		if date.Before(startDate.AddDate(0, 0, iteration.Duration)) {
+			return &iteration, nil
+		}
+		before = &iteration
+	}
+	return nil, fmt.Errorf("no iteration on %s: the last iteration %s ended", date.Format(time.DateOnly), before.Title)
+}
+
+// relativeIteration returns the iteration offset from the one in progress on the date, in the order of the start dates.
+// A positive offset counts the iterations starting after the date, and a negative offset the ones ended by the date,
+// so the relative iterations are resolved even if the date falls in a gap.
+func relativeIteration(
+	field *github.ProjectV2IterationField, date time.Time, offset int,
+) (*github.ProjectV2IterationFieldIteration, error) {
+	if offset == 0 {
+		return currentIteration(field, date)
+	}
+
+	var before, after []github.ProjectV2IterationFieldIteration
+	for _, iteration := range allIterations(field) {
+		startDate, err := time.Parse(time.DateOnly, iteration.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date of iteration %s: %w", iteration.Title, err)
+		}
+		endDate := startDate.AddDate(0, 0, iteration.Duration)
+		switch {
+		case startDate.After(date):
+			after = append(after, iteration)
+		case !endDate.After(date):
+			before = append(before, iteration)
+		}
+	}
+
+	if offset > 0 {
+		if offset > len(after) {
+			return nil, fmt.Errorf("no iteration %d after %s in field %s", offset, date.Format(time.DateOnly), field.Name)
+		}
+		return &after[offset-1], nil
+	}
+	if -offset > len(before) {
+		return nil, fmt.Errorf("no iteration %d before %s in field %s", -offset, date.Format(time.DateOnly), field.Name)
+	}
+	return &before[len(before)+offset], nil
+}
+
+// IterationSelector holds the flags selecting the iteration to set.
+type IterationSelector struct {
+	Current        bool
+	Next           bool
+	Previous       bool
+	Offset         optionalInt
+	IterationTitle string
+	IterationID    string
+	IterationDate  string
+	Date           string
+}
+
+// iterationSelectorFlags is the names of the flags selecting an iteration.
+//
+//nolint:gochecknoglobals
+var iterationSelectorFlags = []string{"current", "next", "previous", "offset", "iteration", "iteration-id", "iteration-date"}
+
+func addIterationSelectorFlags(cmd *cobra.Command, selector *IterationSelector) {
+	cmd.Flags().BoolVar(&selector.Current, "current", false, "Set current iteration as the iteration field value")
+	cmd.Flags().BoolVar(&selector.Next, "next", false, "Set next iteration as the iteration field value")
+	cmd.Flags().BoolVar(&selector.Previous, "previous", false, "Set previous iteration as the iteration field value")
+	cmd.Flags().Var(&selector.Offset, "offset",
+		"Set the iteration N iterations after the current one as the iteration field value (negative for before)")
+	cmd.Flags().StringVar(&selector.IterationTitle, "iteration", "", "Iteration title to set")
+	cmd.Flags().StringVar(&selector.IterationID, "iteration-id", "", "Iteration ID to set")
+	cmd.Flags().StringVar(&selector.IterationDate, "iteration-date", "",
+		"Set the iteration containing the date (YYYY-MM-DD) as the iteration field value")
+	cmd.Flags().StringVar(&selector.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration (default: today in the local time zone)")
+}
+
+// relativeOffset returns the offset of the iteration selected relatively to the current one.
+func (s *IterationSelector) relativeOffset() (int, bool) {
+	switch {
+	case s.Current:
+		return 0, true
+	case s.Next:
+		return 1, true
+	case s.Previous:
+		return -1, true
+	case s.Offset.set:
+		return s.Offset.value, true
+	default:
+		return 0, false
+	}
+}
+
+// isSet reports whether any iteration is selected.
+func (s *IterationSelector) isSet() bool {
+	_, ok := s.relativeOffset()
+	return ok || len(s.IterationTitle) > 0 || len(s.IterationID) > 0 || len(s.IterationDate) > 0
+}
+
+// resolve returns the selected iteration of the field.
+func (s *IterationSelector) resolve(field *github.ProjectV2IterationField) (*github.ProjectV2IterationFieldIteration, error) {
+	switch {
+	case len(s.IterationTitle) > 0:
+		return findIterationByTitle(field, s.IterationTitle)
+	case len(s.IterationID) > 0:
+		return findIterationByID(field, s.IterationID)
+	case len(s.IterationDate) > 0:
+		date, err := time.Parse(time.DateOnly, s.IterationDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid iteration date: %w", err)
+		}
+		return currentIteration(field, date)
+	}
+
+	offset, ok := s.relativeOffset()
+	if !ok {
+		return nil, errors.New("no iteration is selected")
+	}
+	date, err := resolveDate(s.Date)
+	if err != nil {
+		return nil, err
+	}
+	return relativeIteration(field, date, offset)
+}
+
+// optionalInt is an int flag value which tells whether it is set.
+type optionalInt struct {
+	value int
+	set   bool
+}
+
+func (i *optionalInt) Set(s string) error {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid integer: %w", err)
+	}
+	i.value = v
+	i.set = true
+	return nil
+}
+
+func (i *optionalInt) String() string {
+	return strconv.Itoa(i.value)
+}
+
+func (i *optionalInt) Type() string {
+	return "int"
+}
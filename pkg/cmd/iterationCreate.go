@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type IterationCreateProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type IterationCreateOption struct {
+	ProjectSelector
+
+	FieldName string
+	Count     int
+	Title     string
+	StartDate string
+	Duration  int
+	DryRun    bool
+	Yes       bool
+}
+
+// iterationNumberPlaceholder is replaced with the number of the iteration in the title of a new iteration.
+const iterationNumberPlaceholder = "{n}"
+
+func NewIterationCreateCmd(props *IterationCreateProps) *cobra.Command {
+	opts := new(IterationCreateOption)
+
+	// iterationCreateCmd represents the iteration-create command.
+	iterationCreateCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "iteration-create" + projectURLArgs,
+		Short: "Create iterations in an iteration field",
+		Long: `Create iterations in an iteration field.
+New iterations follow the last iteration of the field, one after another.
+"{n}" in the title is replaced with the number of the iteration in the field.
+All the iterations of the field are rewritten with new IDs, and the project items are set to them again.
+It asks for confirmation on a terminal, and needs --yes otherwise.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					flags.Flag("field"),
+					projectFlags(),
+				),
+			)
+			if err != nil {
+				return err
+			}
+			if opts.Count < 1 {
+				return fmt.Errorf("flags: count must be positive: %d", opts.Count)
+			}
+			if cmd.Flags().Changed("duration") && opts.Duration < 1 {
+				return fmt.Errorf("flags: duration must be positive: %d", opts.Duration)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			iterationCreateRun(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), props, opts)
+		},
+	}
+
+	iterationCreateCmd.Flags().SortFlags = false
+	iterationCreateCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
+	iterationCreateCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	iterationCreateCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	iterationCreateCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	iterationCreateCmd.Flags().IntVar(&opts.Count, "count", 1, "Number of iterations to create")
+	iterationCreateCmd.Flags().StringVar(&opts.Title, "title", "Iteration "+iterationNumberPlaceholder, "Title of the iterations")
+	iterationCreateCmd.Flags().StringVar(&opts.StartDate, "start-date", "",
+		"Start date (YYYY-MM-DD) of the first iteration (default: the end of the last iteration)")
+	iterationCreateCmd.Flags().IntVar(&opts.Duration, "duration", 0,
+		"Duration of the iterations in days (default: the duration of the field)")
+	iterationCreateCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the iterations to create without creating them")
+	iterationCreateCmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Create the iterations without confirmation")
+
+	return iterationCreateCmd
+}
+
+func iterationCreateRun(in io.Reader, out io.Writer, errOut io.Writer, props *IterationCreateProps, opts *IterationCreateOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	iterationField, err := fetchIterationField(client, project.ID, opts.FieldName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Iteration field ID: " + iterationField.ID)
+
+	iterations := allIterations(iterationField)
+	newIterations, err := newIterationInputs(iterationField, iterations, opts)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	rewrites := keepIterations(iterations)
+	for _, iteration := range newIterations {
+		rewrites = append(rewrites, iterationRewrite{Previous: nil, New: &iteration})
+	}
+
+	if opts.DryRun {
+		_, _ = fmt.Fprint(errOut, formatIterationRewrites(iterationField, rewrites))
+		printIterations(out, *props.OutputFormatJSON, newIterationsPreview(newIterations))
+		return
+	}
+	confirmed, err := confirmIterationRewrites(in, errOut, iterationField, rewrites, opts.Yes)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	if !confirmed {
+		_, _ = fmt.Fprintln(errOut, "Canceled.")
+		return
+	}
+
+	log.Debug("Create iterations")
+	updatedField, err := updateIterations(client, project.ID, iterationField, rewrites)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	// The IDs of the new iterations are known only after the update, so look them up by their start dates
+	// among the iterations with other IDs than the existing ones.
+	created := make([]github.ProjectV2IterationFieldIteration, 0, len(newIterations))
+	for _, iteration := range allIterations(updatedField) {
+		if slices.ContainsFunc(iterations, func(i github.ProjectV2IterationFieldIteration) bool { return i.ID == iteration.ID }) {
+			continue
+		}
+		if slices.ContainsFunc(newIterations, func(i github.ProjectV2Iteration) bool { return i.StartDate == iteration.StartDate }) {
+			created = append(created, iteration)
+		}
+	}
+
+	printIterations(out, *props.OutputFormatJSON, created)
+}
+
+// newIterationsPreview returns the new iterations as the ones of a field, with no IDs as they are not created yet.
+func newIterationsPreview(inputs []github.ProjectV2Iteration) []github.ProjectV2IterationFieldIteration {
+	iterations := make([]github.ProjectV2IterationFieldIteration, 0, len(inputs))
+	for _, input := range inputs {
+		iterations = append(iterations, github.ProjectV2IterationFieldIteration{
+			ID:        "",
+			Title:     input.Title,
+			StartDate: input.StartDate,
+			Duration:  input.Duration,
+		})
+	}
+	return iterations
+}
+
+func newIterationInputs(
+	field *github.ProjectV2IterationField, iterations []github.ProjectV2IterationFieldIteration, opts *IterationCreateOption,
+) ([]github.ProjectV2Iteration, error) {
+	duration := opts.Duration
+	if duration == 0 {
+		duration = field.Configuration.Duration
+	}
+
+	var startDate time.Time
+	var err error
+	switch {
+	case len(opts.StartDate) > 0:
+		startDate, err = time.Parse(time.DateOnly, opts.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date: %w", err)
+		}
+	case len(iterations) > 0:
+		startDate, err = iterationEndDate(iterations[len(iterations)-1])
+		if err != nil {
+			return nil, err
+		}
+	case len(field.Configuration.StartDate) > 0:
+		startDate, err = time.Parse(time.DateOnly, field.Configuration.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date of field %s: %w", field.Name, err)
+		}
+	default:
+		startDate = time.Now()
+	}
+
+	return consecutiveIterations(startDate, duration, opts.Count, opts.Title, len(iterations)+1), nil
+}
+
+// consecutiveIterations returns count iterations starting on startDate one after another.
+// The placeholder in the title is replaced with the number of the iteration, starting from firstNumber.
+func consecutiveIterations(startDate time.Time, duration int, count int, title string, firstNumber int) []github.ProjectV2Iteration {
+	inputs := make([]github.ProjectV2Iteration, 0, count)
+	for i := range count {
+		inputs = append(inputs, github.ProjectV2Iteration{
+			Duration:  duration,
+			StartDate: startDate.AddDate(0, 0, i*duration).Format(time.DateOnly),
+			Title:     strings.ReplaceAll(title, iterationNumberPlaceholder, strconv.Itoa(firstNumber+i)),
+		})
+	}
+	return inputs
+}
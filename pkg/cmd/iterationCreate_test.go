@@ -0,0 +1,105 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func TestIterationCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "after the last iteration",
+			args: []string{
+				"iteration-create", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint",
+				"--count", "2", "--title", "Sprint {n}",
+			},
+			want: "" +
+				"Title     StartDate   Duration  ID      \n" +
+				"Sprint 6  2026-11-16        14  iteration_6\n" +
+				"Sprint 7  2026-11-30        14  iteration_7\n",
+		},
+		{
+			name: "with start date and duration",
+			args: []string{
+				"iteration-create", "--yes", "--owner", "octocat", "--project", "3", "--field", "Week",
+				"--start-date", "2026-11-02", "--duration", "14",
+			},
+			want: "" +
+				"Title        StartDate   Duration  ID      \n" +
+				"Iteration 3  2026-11-02        14  iteration_3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server, test.args...)
+			assertOutput(t, test.want, got)
+		})
+	}
+}
+
+func TestIterationCreateKeepsItemValues(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	runCmd(t, server, "iteration-create", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint")
+
+	got := runCmd(t, server, "list", "--owner", "acme", "--project", "1", "--field", "Sprint")
+	want := "" +
+		"Title        StartDate   Duration  ID      \n" +
+		"Sprint 3     2026-10-05        14  iteration_3\n" +
+		"Sprint 4     2026-10-19        14  iteration_4\n" +
+		"Sprint 5     2026-11-02        14  iteration_5\n" +
+		"Iteration 6  2026-11-16        14  iteration_6\n"
+	assertOutput(t, want, got)
+	// The iterations get new IDs, and the items are set to them again.
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "iteration_2")
+	assertFieldValue(t, server, "PVTI_5", "Sprint", "iteration_4")
+}
+
+func TestIterationCreateDryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"iteration-create", "--owner", "acme", "--project", "1", "--field", "Sprint", "--title", "Sprint {n}", "--dry-run")
+	want := "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 6  2026-11-16        14          \n"
+	assertOutput(t, want, got)
+
+	assertNoOperation(t, server, "updateProjectV2Field")
+}
+
+func TestIterationCreateWithoutConfirmation(t *testing.T) {
+	t.Parallel()
+
+	got, code := runCmdInSubprocess(t, func(*githubtest.Server) {},
+		"iteration-create", "--owner", "acme", "--project", "1", "--field", "Sprint")
+	assertOutput(t, "", got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+}
+
+func TestIterationCreateFailsToRestoreItemValues(t *testing.T) {
+	t.Parallel()
+
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) { server.FailItem("PVTI_1") },
+		"iteration-create", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint")
+	assertOutput(t, "", got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+}
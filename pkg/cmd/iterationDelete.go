@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type IterationDeleteProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type IterationDeleteOption struct {
+	ProjectSelector
+
+	FieldName      string
+	IterationTitle string
+	DryRun         bool
+	Yes            bool
+}
+
+func NewIterationDeleteCmd(props *IterationDeleteProps) *cobra.Command {
+	opts := new(IterationDeleteOption)
+
+	// iterationDeleteCmd represents the iteration-delete command.
+	iterationDeleteCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "iteration-delete" + projectURLArgs,
+		Short: "Delete an iteration from an iteration field",
+		Long: `Delete an iteration from an iteration field.
+Project items in the deleted iteration lose their iteration field value.
+All the iterations of the field are rewritten with new IDs, and the project items are set to them again.
+It asks for confirmation on a terminal, and needs --yes otherwise.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					flags.Flag("field"),
+					projectFlags(),
+					flags.Flag("iteration"),
+				),
+			)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			iterationDeleteRun(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), props, opts)
+		},
+	}
+
+	iterationDeleteCmd.Flags().SortFlags = false
+	iterationDeleteCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
+	iterationDeleteCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	iterationDeleteCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	iterationDeleteCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	iterationDeleteCmd.Flags().StringVar(&opts.IterationTitle, "iteration", "", "Title of the iteration to delete")
+	iterationDeleteCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the iteration to delete without deleting it")
+	iterationDeleteCmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Delete the iteration without confirmation")
+	_ = iterationDeleteCmd.MarkFlagRequired("iteration")
+
+	return iterationDeleteCmd
+}
+
+func iterationDeleteRun(in io.Reader, out io.Writer, errOut io.Writer, props *IterationDeleteProps, opts *IterationDeleteOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	iterationField, err := fetchIterationField(client, project.ID, opts.FieldName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Iteration field ID: " + iterationField.ID)
+
+	target, err := findIterationByTitle(iterationField, opts.IterationTitle)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Iteration ID: " + target.ID)
+
+	rewrites := keepIterations(allIterations(iterationField))
+	for i := range rewrites {
+		if rewrites[i].Previous.ID == target.ID {
+			rewrites[i].New = nil
+		}
+	}
+
+	if opts.DryRun {
+		_, _ = fmt.Fprint(errOut, formatIterationRewrites(iterationField, rewrites))
+		printIterations(out, *props.OutputFormatJSON, []github.ProjectV2IterationFieldIteration{*target})
+		return
+	}
+	confirmed, err := confirmIterationRewrites(in, errOut, iterationField, rewrites, opts.Yes)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	if !confirmed {
+		_, _ = fmt.Fprintln(errOut, "Canceled.")
+		return
+	}
+
+	log.Debug("Delete the iteration")
+	_, err = updateIterations(client, project.ID, iterationField, rewrites)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	printIterations(out, *props.OutputFormatJSON, []github.ProjectV2IterationFieldIteration{*target})
+}
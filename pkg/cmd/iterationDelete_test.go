@@ -0,0 +1,40 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestIterationDelete(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"iteration-delete", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint", "--iteration", "Sprint 4")
+	want := "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 4  2026-10-19        14  sprint_4\n"
+	assertOutput(t, want, got)
+
+	got = runCmd(t, server, "list", "--owner", "acme", "--project", "1", "--field", "Sprint")
+	want = "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 3  2026-10-05        14  iteration_3\n" +
+		"Sprint 5  2026-11-02        14  iteration_4\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_5", "Sprint", nil)
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "iteration_3")
+}
+
+func TestIterationDeleteDryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"iteration-delete", "--owner", "acme", "--project", "1", "--field", "Sprint", "--iteration", "Sprint 4", "--dry-run")
+	want := "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 4  2026-10-19        14  sprint_4\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_5", "Sprint", "sprint_4")
+	assertNoOperation(t, server, "updateProjectV2Field")
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type IterationEditProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type IterationEditOption struct {
+	ProjectSelector
+
+	FieldName      string
+	IterationTitle string
+	Title          string
+	StartDate      string
+	Duration       int
+	DryRun         bool
+	Yes            bool
+}
+
+func NewIterationEditCmd(props *IterationEditProps) *cobra.Command {
+	opts := new(IterationEditOption)
+
+	// iterationEditCmd represents the iteration-edit command.
+	iterationEditCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "iteration-edit" + projectURLArgs,
+		Short: "Edit an iteration in an iteration field",
+		Long: `Edit the title, start date or duration of an iteration in an iteration field.
+All the iterations of the field are rewritten with new IDs, and the project items are set to them again.
+It asks for confirmation on a terminal, and needs --yes otherwise.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					flags.Flag("field"),
+					projectFlags(),
+					flags.Flag("iteration"),
+				),
+			)
+			if err != nil {
+				return err
+			}
+			if len(opts.StartDate) > 0 {
+				_, err = time.Parse(time.DateOnly, opts.StartDate)
+				if err != nil {
+					return fmt.Errorf("flags: invalid start date: %w", err)
+				}
+			}
+			if cmd.Flags().Changed("duration") && opts.Duration < 1 {
+				return fmt.Errorf("flags: duration must be positive: %d", opts.Duration)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			iterationEditRun(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), props, opts)
+		},
+	}
+
+	iterationEditCmd.Flags().SortFlags = false
+	iterationEditCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
+	iterationEditCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	iterationEditCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	iterationEditCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	iterationEditCmd.Flags().StringVar(&opts.IterationTitle, "iteration", "", "Title of the iteration to edit")
+	iterationEditCmd.Flags().StringVar(&opts.Title, "title", "", "New title of the iteration")
+	iterationEditCmd.Flags().StringVar(&opts.StartDate, "start-date", "", "New start date (YYYY-MM-DD) of the iteration")
+	iterationEditCmd.Flags().IntVar(&opts.Duration, "duration", 0, "New duration of the iteration in days")
+	iterationEditCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the edited iteration without editing it")
+	iterationEditCmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Edit the iteration without confirmation")
+	iterationEditCmd.MarkFlagsOneRequired("title", "start-date", "duration")
+	_ = iterationEditCmd.MarkFlagRequired("iteration")
+
+	return iterationEditCmd
+}
+
+func iterationEditRun(in io.Reader, out io.Writer, errOut io.Writer, props *IterationEditProps, opts *IterationEditOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	iterationField, err := fetchIterationField(client, project.ID, opts.FieldName)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Iteration field ID: " + iterationField.ID)
+
+	target, err := findIterationByTitle(iterationField, opts.IterationTitle)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug("Iteration ID: " + target.ID)
+
+	rewrites := keepIterations(allIterations(iterationField))
+	var edited github.ProjectV2IterationFieldIteration
+	for _, rewrite := range rewrites {
+		if rewrite.Previous.ID != target.ID {
+			continue
+		}
+		if len(opts.Title) > 0 {
+			rewrite.New.Title = opts.Title
+		}
+		if len(opts.StartDate) > 0 {
+			rewrite.New.StartDate = opts.StartDate
+		}
+		if opts.Duration > 0 {
+			rewrite.New.Duration = opts.Duration
+		}
+		edited = github.ProjectV2IterationFieldIteration{
+			ID:        target.ID,
+			Title:     rewrite.New.Title,
+			StartDate: rewrite.New.StartDate,
+			Duration:  rewrite.New.Duration,
+		}
+	}
+
+	if opts.DryRun {
+		_, _ = fmt.Fprint(errOut, formatIterationRewrites(iterationField, rewrites))
+		printIterations(out, *props.OutputFormatJSON, []github.ProjectV2IterationFieldIteration{edited})
+		return
+	}
+	confirmed, err := confirmIterationRewrites(in, errOut, iterationField, rewrites, opts.Yes)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	if !confirmed {
+		_, _ = fmt.Fprintln(errOut, "Canceled.")
+		return
+	}
+
+	log.Debug("Edit the iteration")
+	updatedField, err := updateIterations(client, project.ID, iterationField, rewrites)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	result, err := findEditedIteration(updatedField, edited)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	printIterations(out, *props.OutputFormatJSON, []github.ProjectV2IterationFieldIteration{*result})
+}
+
+// findEditedIteration returns the edited iteration of the updated field by its ID.
+// If the iteration got a new ID, it is looked up by its title and start date, which must match one iteration.
+func findEditedIteration(
+	field *github.ProjectV2IterationField, edited github.ProjectV2IterationFieldIteration,
+) (*github.ProjectV2IterationFieldIteration, error) {
+	iteration, err := findIterationByID(field, edited.ID)
+	if err == nil {
+		return iteration, nil
+	}
+
+	var found []github.ProjectV2IterationFieldIteration
+	for _, iteration := range allIterations(field) {
+		if iteration.StartDate == edited.StartDate && iteration.Title == edited.Title {
+			found = append(found, iteration)
+		}
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("cannot tell the edited iteration %s: %d iterations start on %s with the title",
+			edited.Title, len(found), edited.StartDate)
+	}
+	return &found[0], nil
+}
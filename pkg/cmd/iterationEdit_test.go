@@ -0,0 +1,77 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestIterationEdit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "rename",
+			args: []string{
+				"iteration-edit", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint",
+				"--iteration", "Sprint 4", "--title", "Release sprint",
+			},
+			want: "" +
+				"Title           StartDate   Duration  ID      \n" +
+				"Release sprint  2026-10-19        14  iteration_4\n",
+		},
+		{
+			name: "change duration",
+			args: []string{
+				"iteration-edit", "--yes", "--owner", "acme", "--project", "1", "--field", "Sprint",
+				"--iteration", "Sprint 5", "--duration", "7", "--json",
+			},
+			want: `{
+  "iterations": [
+    {
+      "id": "iteration_5",
+      "title": "Sprint 5",
+      "startDate": "2026-11-02",
+      "duration": 7
+    }
+  ]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server, test.args...)
+			assertOutput(t, test.want, got)
+			assertFieldValue(t, server, "PVTI_5", "Sprint", "iteration_4")
+		})
+	}
+}
+
+func TestIterationEditDryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"iteration-edit", "--owner", "acme", "--project", "1", "--field", "Sprint",
+		"--iteration", "Sprint 4", "--title", "Release sprint", "--dry-run")
+	want := "" +
+		"Title           StartDate   Duration  ID      \n" +
+		"Release sprint  2026-10-19        14  sprint_4\n"
+	assertOutput(t, want, got)
+
+	got = runCmd(t, server, "list", "--owner", "acme", "--project", "1", "--field", "Sprint")
+	want = "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 3  2026-10-05        14  sprint_3\n" +
+		"Sprint 4  2026-10-19        14  sprint_4\n" +
+		"Sprint 5  2026-11-02        14  sprint_5\n"
+	assertOutput(t, want, got)
+	assertNoOperation(t, server, "updateProjectV2Field")
+}
@@ -0,0 +1,121 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tasshi-me/gh-iteration/pkg/cmd"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+func newIterationField(
+	completed []github.ProjectV2IterationFieldIteration, active []github.ProjectV2IterationFieldIteration,
+) *github.ProjectV2IterationField {
+	field := &github.ProjectV2IterationField{ID: "PVTIF_sprint", Name: "Sprint"} //nolint:exhaustruct
+	field.Configuration.CompletedIterations = completed
+	field.Configuration.Iterations = active
+	return field
+}
+
+func date(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestIterationResolution(t *testing.T) {
+	t.Parallel()
+
+	sprint1 := github.ProjectV2IterationFieldIteration{ID: "sprint_1", Title: "Sprint 1", StartDate: "2026-09-21", Duration: 14}
+	sprint2 := github.ProjectV2IterationFieldIteration{ID: "sprint_2", Title: "Sprint 2", StartDate: "2026-10-05", Duration: 7}
+	sprint3 := github.ProjectV2IterationFieldIteration{ID: "sprint_3", Title: "Sprint 3", StartDate: "2026-10-19", Duration: 14}
+	field := newIterationField(
+		[]github.ProjectV2IterationFieldIteration{sprint2, sprint1},
+		[]github.ProjectV2IterationFieldIteration{sprint3},
+	)
+
+	tests := []struct {
+		name         string
+		date         string
+		wantCurrent  string
+		wantNext     string
+		wantPrevious string
+	}{
+		{name: "first day", date: "2026-09-21", wantCurrent: "sprint_1", wantNext: "sprint_2", wantPrevious: ""},
+		{name: "last day", date: "2026-10-11", wantCurrent: "sprint_2", wantNext: "sprint_3", wantPrevious: "sprint_1"},
+		{name: "gap", date: "2026-10-12", wantCurrent: "", wantNext: "sprint_3", wantPrevious: "sprint_2"},
+		{name: "active iteration", date: "2026-10-20", wantCurrent: "sprint_3", wantNext: "", wantPrevious: "sprint_2"},
+		{name: "before the first iteration", date: "2026-09-20", wantCurrent: "", wantNext: "sprint_1", wantPrevious: ""},
+		{name: "after the last iteration", date: "2026-11-02", wantCurrent: "", wantNext: "", wantPrevious: "sprint_3"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := date(t, test.date)
+			assertIteration(t, "current", test.wantCurrent)(cmd.CurrentIteration(field, d))
+			assertIteration(t, "next", test.wantNext)(cmd.RelativeIteration(field, d, 1))
+			assertIteration(t, "previous", test.wantPrevious)(cmd.RelativeIteration(field, d, -1))
+		})
+	}
+}
+
+func TestFindIterationByTitle(t *testing.T) {
+	t.Parallel()
+
+	field := newIterationField(
+		[]github.ProjectV2IterationFieldIteration{
+			{ID: "sprint_1", Title: "Sprint", StartDate: "2026-09-21", Duration: 14},
+		},
+		[]github.ProjectV2IterationFieldIteration{
+			{ID: "sprint_2", Title: "Sprint", StartDate: "2026-10-05", Duration: 14},
+			{ID: "sprint_3", Title: "Sprint 3", StartDate: "2026-10-19", Duration: 14},
+		},
+	)
+
+	got, err := cmd.FindIterationByTitle(field, "Sprint 3")
+	if err != nil || got.ID != "sprint_3" {
+		t.Errorf("want sprint_3, got %v, %v", got, err)
+	}
+
+	_, err = cmd.FindIterationByTitle(field, "Sprint")
+	want := "2 iterations match the title Sprint, specify one by ID or date: " +
+		"Sprint (ID: sprint_1, StartDate: 2026-09-21), Sprint (ID: sprint_2, StartDate: 2026-10-05)"
+	if err == nil || err.Error() != want {
+		t.Errorf("wrong error\nwant: %s\ngot: %v", want, err)
+	}
+}
+
+func TestIterationResolutionWithoutIterations(t *testing.T) {
+	t.Parallel()
+
+	field := newIterationField(nil, nil)
+	_, err := cmd.CurrentIteration(field, date(t, "2026-10-18"))
+	if err == nil {
+		t.Error("no error for a field without iterations")
+	}
+}
+
+// assertIteration returns a function asserting the ID of the resolved iteration. An empty ID expects an error.
+func assertIteration(t *testing.T, name string, wantID string) func(*github.ProjectV2IterationFieldIteration, error) {
+	t.Helper()
+
+	return func(got *github.ProjectV2IterationFieldIteration, err error) {
+		t.Helper()
+
+		switch {
+		case len(wantID) == 0 && err == nil:
+			t.Errorf("%s: want error, got %s", name, got.ID)
+		case len(wantID) > 0 && err != nil:
+			t.Errorf("%s: want %s, got error: %v", name, wantID, err)
+		case len(wantID) > 0 && got.ID != wantID:
+			t.Errorf("%s: want %s, got %s", name, wantID, got.ID)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tasshi-me/gh-iteration/pkg/config"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// journalEntry records a change of the iteration field value of a project item.
+// An empty iteration ID means that the field value is empty.
+type journalEntry struct {
+	ProjectID           string `json:"projectId"`
+	ItemID              string `json:"itemId"`
+	FieldID             string `json:"fieldId"`
+	PreviousIterationID string `json:"previousIterationId"`
+	NewIterationID      string `json:"newIterationId"`
+}
+
+func newJournalEntry(projectID string, fieldID string, change itemsEditChange) journalEntry {
+	return journalEntry{
+		ProjectID:           projectID,
+		ItemID:              change.ItemID,
+		FieldID:             fieldID,
+		PreviousIterationID: change.FromIterationID,
+		NewIterationID:      change.ToIterationID,
+	}
+}
+
+// journalWriter appends journal entries to a file as JSON lines.
+// The file is opened on the first entry, so that a run without changes leaves no journal.
+type journalWriter struct {
+	open    func() (*os.File, error)
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// newJournalWriter returns a journal writer appending to the file.
+func newJournalWriter(path string) *journalWriter {
+	return &journalWriter{
+		open: func() (*os.File, error) {
+			return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) //nolint:mnd,wrapcheck
+		},
+		file:    nil,
+		encoder: nil,
+	}
+}
+
+// newRunJournalWriter returns a journal writer to a new file of the run in the journal directory,
+// named after the current time so that the files are sorted in the order of the runs.
+func newRunJournalWriter() (*journalWriter, error) {
+	dir, err := journalDir()
+	if err != nil {
+		return nil, err
+	}
+	return &journalWriter{
+		open: func() (*os.File, error) {
+			err := os.MkdirAll(dir, 0o700) //nolint:mnd
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return os.CreateTemp(dir, time.Now().UTC().Format("20060102T150405.000000000Z")+"-*"+journalExt) //nolint:wrapcheck
+		},
+		file:    nil,
+		encoder: nil,
+	}, nil
+}
+
+// openJournal returns the writer of the journal file given by --journal, or of a new file of the run by default.
+func openJournal(path string) (*journalWriter, error) {
+	if len(path) > 0 {
+		return newJournalWriter(path), nil
+	}
+	return newRunJournalWriter()
+}
+
+func (w *journalWriter) write(entry journalEntry) error {
+	if w.file == nil {
+		file, err := w.open()
+		if err != nil {
+			return fmt.Errorf("failed to open the journal: %w", err)
+		}
+		log.Debug("Journal: " + file.Name())
+		w.file = file
+		w.encoder = json.NewEncoder(file)
+	}
+	err := w.encoder.Encode(entry)
+	if err != nil {
+		return fmt.Errorf("failed to write the journal: %w", err)
+	}
+	return nil
+}
+
+func (w *journalWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close the journal: %w", err)
+	}
+	return nil
+}
+
+const (
+	// journalDirHelp is the journal directory shown in the help.
+	journalDirHelp = "~/.config/gh-iteration/journal"
+	// journalExt is the extension of the journal files of the runs.
+	journalExt = ".jsonl"
+	// undoneJournalExt is appended to the journal file of a run once it is undone.
+	undoneJournalExt = ".undone"
+)
+
+// journalDir returns the directory of the journal files of the runs, next to the config file of the user.
+func journalDir() (string, error) {
+	path, err := config.GlobalPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the journal directory: %w", err)
+	}
+	return filepath.Join(filepath.Dir(path), "journal"), nil
+}
+
+// lastRunJournal returns the journal file of the last run not undone yet.
+func lastRunJournal() (string, error) {
+	dir, err := journalDir()
+	if err != nil {
+		return "", err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+journalExt))
+	if err != nil {
+		return "", fmt.Errorf("failed to list the journals: %w", err)
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no journal to undo in %s", dir)
+	}
+	slices.Sort(paths)
+	return paths[len(paths)-1], nil
+}
+
+// readJournal reads the journal entries of a file in the written order.
+func readJournal(path string) ([]journalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the journal: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(file)
+
+	var entries []journalEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry journalEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the journal at line %d: %w", line, err)
+		}
+		if len(entry.ProjectID) == 0 || len(entry.ItemID) == 0 || len(entry.FieldID) == 0 {
+			return nil, fmt.Errorf("invalid journal entry at line %d: projectId, itemId and fieldId are required", line)
+		}
+		entries = append(entries, entry)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the journal: %w", err)
+	}
+	return entries, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -15,13 +16,16 @@ import (
 
 type ListProps struct {
 	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
 }
 
 type ListOption struct {
-	ProjectOwner  string
-	ProjectNumber int
-	FieldName     string
-	Completed     bool
+	IterationSelector
+	ProjectSelector
+
+	FieldName string
+	Completed bool
 }
 
 func NewListCmd(props *ListProps) *cobra.Command {
@@ -29,26 +33,20 @@ func NewListCmd(props *ListProps) *cobra.Command {
 
 	// listCmd represents the list command.
 	listCmd := &cobra.Command{ //nolint:exhaustruct
-		Use:   "list",
+		Use:   "list" + projectURLArgs,
 		Short: "List the iterations for an iteration field",
 		Long:  `List the iterations for an iteration field`,
-		Args:  cobra.NoArgs,
-		PreRunE: func(cmd *cobra.Command, _ []string) error {
-			validator := flags.NewValidator(
+		Args:  cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
 				flags.And(
 					flags.Flag("field"),
-					flags.Flag("project"),
-					flags.Flag("owner"),
+					projectFlags(),
 				),
 			)
-			err := validator.Validate(cmd)
-			if err != nil {
-				return fmt.Errorf("flags: %w", err)
-			}
-			return nil
 		},
-		Run: func(_ *cobra.Command, _ []string) {
-			listRun(props, opts)
+		Run: func(cmd *cobra.Command, _ []string) {
+			listRun(cmd.OutOrStdout(), props, opts)
 		},
 	}
 
@@ -56,16 +54,27 @@ func NewListCmd(props *ListProps) *cobra.Command {
 	listCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
 	listCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
 	listCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	listCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
 	listCmd.Flags().BoolVar(&opts.Completed, "completed", false, "List completed iterations")
-	_ = listCmd.MarkFlagRequired("field")
-	_ = listCmd.MarkFlagRequired("project")
-	_ = listCmd.MarkFlagRequired("owner")
+	listCmd.Flags().BoolVar(&opts.Current, "current", false, "List only the current iteration")
+	listCmd.Flags().BoolVar(&opts.Next, "next", false, "List only the next iteration")
+	listCmd.Flags().BoolVar(&opts.Previous, "previous", false, "List only the previous iteration")
+	listCmd.Flags().Var(&opts.Offset, "offset", "List only the iteration N iterations after the current one (negative for before)")
+	listCmd.Flags().StringVar(&opts.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration (default: today in the local time zone)")
+	listCmd.MarkFlagsMutuallyExclusive("completed", "current", "next", "previous", "offset")
 
 	return listCmd
 }
 
-func listRun(props *ListProps, opts *ListOption) {
-	iterationField, err := retrieveIterationField(opts)
+func listRun(out io.Writer, props *ListProps, opts *ListOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	iterationField, err := retrieveIterationField(client, &opts.ProjectSelector, opts.FieldName)
 	if err != nil {
 		log.Error(err)
 		os.Exit(1)
@@ -73,46 +82,65 @@ func listRun(props *ListProps, opts *ListOption) {
 	log.Debug("Iteration field ID: " + iterationField.ID)
 
 	var iterations []github.ProjectV2IterationFieldIteration
-	if opts.Completed {
+	if opts.isSet() {
+		iteration, err := opts.resolve(iterationField)
+		if err != nil {
+			log.Error(fmt.Errorf("failed to resolve the iteration: %w", err))
+			os.Exit(1)
+		}
+		iterations = []github.ProjectV2IterationFieldIteration{*iteration}
+	} else if opts.Completed {
 		iterations = iterationField.Configuration.CompletedIterations
 	} else {
 		iterations = iterationField.Configuration.Iterations
 	}
 
-	if *props.OutputFormatJSON {
-		s, err := formatIterationsJSON(iterations)
-		if err != nil {
-			log.Error(err)
-			os.Exit(1)
-		}
-		_, _ = fmt.Fprint(os.Stdout, s)
-	} else {
-		s := formatIterationsPlain(iterations)
-		_, _ = fmt.Fprint(os.Stdout, s)
+	printIterations(out, *props.OutputFormatJSON, iterations)
+}
+
+func retrieveIterationField(
+	client *github.Client, selector *ProjectSelector, fieldName string,
+) (*github.ProjectV2IterationField, error) {
+	project, err := retrieveProject(client, selector)
+	if err != nil {
+		return nil, err
 	}
+	return fetchIterationField(client, project.ID, fieldName)
 }
 
-func retrieveIterationField(opts *ListOption) (*github.ProjectV2IterationField, error) {
+func fetchIterationField(client *github.Client, projectID string, fieldName string) (*github.ProjectV2IterationField, error) {
+	log.Debug("Retrieve an iteration field by field name and project")
+	i, err := client.FetchIterationFieldByName(projectID, fieldName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err)
+	}
+	return i, nil
+}
+
+func retrieveProject(client *github.Client, selector *ProjectSelector) (*github.Project, error) {
+	err := selector.checkHost(client)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Debug("Retrieve owner by login name")
-	projectOwner, err := github.FetchOwnerByLogin(opts.ProjectOwner)
+	projectOwner, err := client.FetchOwnerByLogin(selector.ProjectOwner)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve owner by owner login: %w", err)
 	}
 	log.Debug("Owner: " + projectOwner.Login)
+	err = selector.checkOwnerType(projectOwner)
+	if err != nil {
+		return nil, err
+	}
 
 	log.Debug("Retrieve project by owner and project number")
-	project, err := github.FetchProjectByNumber(opts.ProjectNumber, projectOwner.ID)
+	project, err := client.FetchProjectByNumber(selector.ProjectNumber, projectOwner.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a project by project number: %w", err)
 	}
 	log.Debug("Project ID: " + project.ID)
-
-	log.Debug("Retrieve an iteration field by field name and project")
-	i, err := github.FetchIterationFieldByName(project.ID, opts.FieldName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err)
-	}
-	return i, nil
+	return project, nil
 }
 
 func formatIterationsPlain(iterations []github.ProjectV2IterationFieldIteration) string {
@@ -148,13 +176,7 @@ type JSONFormattedIteration struct {
 func formatIterationsJSON(iterations []github.ProjectV2IterationFieldIteration) (string, error) {
 	iters := make([]JSONFormattedIteration, 0, len(iterations))
 	for _, iteration := range iterations {
-		iter := JSONFormattedIteration{
-			ID:        iteration.ID,
-			Title:     iteration.Title,
-			StartDate: iteration.StartDate,
-			Duration:  iteration.Duration,
-		}
-		iters = append(iters, iter)
+		iters = append(iters, formatIterationJSON(&iteration))
 	}
 	obj := JSONFormattedIterations{Iterations: iters}
 
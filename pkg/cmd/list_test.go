@@ -0,0 +1,78 @@
+package cmd_test
+
+import (
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "iterations",
+			args: []string{"list", "--owner", "acme", "--project", "1", "--field", "Sprint"},
+			want: "" +
+				"Title     StartDate   Duration  ID      \n" +
+				"Sprint 3  2026-10-05        14  sprint_3\n" +
+				"Sprint 4  2026-10-19        14  sprint_4\n" +
+				"Sprint 5  2026-11-02        14  sprint_5\n",
+		},
+		{
+			name: "completed iterations",
+			args: []string{"list", "--owner", "acme", "--project", "1", "--field", "Sprint", "--completed"},
+			want: "" +
+				"Title     StartDate   Duration  ID      \n" +
+				"Sprint 2  2026-09-21        14  sprint_2\n" +
+				"Sprint 1  2026-09-07        14  sprint_1\n",
+		},
+		{
+			name: "next iteration",
+			args: []string{"list", "--owner", "acme", "--project", "1", "--field", "Sprint", "--next", "--date", fixtureToday},
+			want: "" +
+				"Title     StartDate   Duration  ID      \n" +
+				"Sprint 4  2026-10-19        14  sprint_4\n",
+		},
+		{
+			name: "iteration by offset",
+			args: []string{"list", "--owner", "acme", "--project", "1", "--field", "Sprint", "--offset", "-2", "--date", fixtureToday},
+			want: "" +
+				"Title     StartDate   Duration  ID      \n" +
+				"Sprint 1  2026-09-07        14  sprint_1\n",
+		},
+		{
+			name: "user project in JSON",
+			args: []string{"list", "--owner", "octocat", "--project", "3", "--field", "Week", "--json"},
+			want: `{
+  "iterations": [
+    {
+      "id": "week_1",
+      "title": "Week 1",
+      "startDate": "2026-10-12",
+      "duration": 7
+    },
+    {
+      "id": "week_2",
+      "title": "Week 2",
+      "startDate": "2026-10-19",
+      "duration": 7
+    }
+  ]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(t)
+			got := runCmd(t, server, test.args...)
+			assertOutput(t, test.want, got)
+		})
+	}
+}
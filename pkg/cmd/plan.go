@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// itemsEditPlan records the changes of the iteration field values resolved by items-edit, to be applied later.
+type itemsEditPlan struct {
+	ProjectID string            `json:"projectId"`
+	FieldID   string            `json:"fieldId"`
+	Changes   []itemsEditChange `json:"changes"`
+}
+
+// itemsEditChange is a change of the iteration field value of a project item.
+// An empty iteration ID means that the field value is empty.
+type itemsEditChange struct {
+	ItemID          string `json:"itemId"`
+	FromIterationID string `json:"fromIterationId"`
+	ToIterationID   string `json:"toIterationId"`
+}
+
+func writePlan(path string, plan itemsEditPlan) error {
+	bytes, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the plan: %w", err)
+	}
+	err = os.WriteFile(path, append(bytes, '\n'), 0o600) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to write the plan: %w", err)
+	}
+	return nil
+}
+
+func readPlan(path string) (*itemsEditPlan, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the plan: %w", err)
+	}
+	var plan itemsEditPlan
+	err = json.Unmarshal(bytes, &plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the plan: %w", err)
+	}
+	if len(plan.ProjectID) == 0 || len(plan.FieldID) == 0 {
+		return nil, fmt.Errorf("invalid plan %s: projectId and fieldId are required", path)
+	}
+	return &plan, nil
+}
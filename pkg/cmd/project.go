@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// ProjectSelector holds the flags selecting the project.
+type ProjectSelector struct {
+	ProjectOwner  string
+	ProjectNumber int
+	ProjectURL    string
+	// host and ownerType are the ones given by the project URL, or zero if it is not given.
+	host      string
+	ownerType github.OwnerType
+}
+
+const (
+	// projectURLArgs is the usage of the optional project URL argument.
+	projectURLArgs  = " [<project-url>]"
+	projectURLUsage = "Project URL, such as https://github.com/orgs/<org>/projects/<number>, in place of --project and --owner"
+)
+
+var errInvalidProjectURL = errors.New(
+	"want https://github.com/orgs/<org>/projects/<number> or https://github.com/users/<user>/projects/<number>")
+
+// projectFlags returns the node of the flags selecting the project.
+func projectFlags() flags.Node {
+	return flags.Or(
+		flags.Flag("project-url"),
+		flags.And(
+			flags.Flag("project"),
+			flags.Flag("owner"),
+		),
+	)
+}
+
+// setProjectURLArg sets --project-url from the project URL argument, if any.
+func setProjectURLArg(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if cmd.Flag("project-url").Changed {
+		return errors.New("you cannot set both the project URL argument and --project-url")
+	}
+	err := cmd.Flags().Set("project-url", args[0])
+	if err != nil {
+		return fmt.Errorf("invalid project URL %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// resolveURL sets the owner and the project number from the project URL, if it is set.
+func (s *ProjectSelector) resolveURL() error {
+	if len(s.ProjectURL) == 0 {
+		return nil
+	}
+	u, err := parseProjectURL(s.ProjectURL)
+	if err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Use --owner %s --project %d from the project URL", u.Login, u.Number))
+	s.ProjectOwner = u.Login
+	s.ProjectNumber = u.Number
+	s.host = u.Host
+	s.ownerType = u.OwnerType
+	return nil
+}
+
+// projectURL is a project URL parsed into its parts.
+type projectURL struct {
+	Host      string
+	OwnerType github.OwnerType
+	Login     string
+	Number    int
+}
+
+// parseProjectURL parses the project URL, such as https://github.com/orgs/<org>/projects/<number>
+// or https://github.com/users/<user>/projects/<number>. The path may continue to a view of the project.
+func parseProjectURL(rawURL string) (*projectURL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project URL %s: %w", rawURL, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || len(u.Hostname()) == 0 {
+		return nil, fmt.Errorf("invalid project URL %s: %w", rawURL, errInvalidProjectURL)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 4 || len(segments[1]) == 0 || segments[2] != "projects" { //nolint:mnd
+		return nil, fmt.Errorf("invalid project URL %s: %w", rawURL, errInvalidProjectURL)
+	}
+	var ownerType github.OwnerType
+	switch segments[0] {
+	case "orgs":
+		ownerType = github.OwnerTypeOrganization
+	case "users":
+		ownerType = github.OwnerTypeUser
+	default:
+		return nil, fmt.Errorf("invalid project URL %s: %w", rawURL, errInvalidProjectURL)
+	}
+	number, err := strconv.Atoi(segments[3])
+	if err != nil || number <= 0 {
+		return nil, fmt.Errorf("invalid project number of the project URL %s: %s", rawURL, segments[3])
+	}
+	return &projectURL{
+		Host:      auth.NormalizeHostname(u.Hostname()),
+		OwnerType: ownerType,
+		Login:     segments[1],
+		Number:    number,
+	}, nil
+}
+
+// checkHost returns an error if the project URL is on another host than the one of the client.
+func (s *ProjectSelector) checkHost(client *github.Client) error {
+	if len(s.host) == 0 || len(client.Host()) == 0 || s.host == client.Host() {
+		return nil
+	}
+	return fmt.Errorf("the project URL is on %s, but gh is set to %s: set GH_HOST=%s to use the project", s.host, client.Host(), s.host)
+}
+
+// checkOwnerType returns an error if the owner is not of the owner type given by the project URL.
+func (s *ProjectSelector) checkOwnerType(owner *github.Owner) error {
+	switch {
+	case s.ownerType == 0 || owner.Type == s.ownerType:
+		return nil
+	case s.ownerType == github.OwnerTypeOrganization:
+		return fmt.Errorf("%s of the project URL is not an organization", owner.Login)
+	default:
+		return fmt.Errorf("%s of the project URL is not a user", owner.Login)
+	}
+}
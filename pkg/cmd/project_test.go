@@ -0,0 +1,147 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/cmd"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func TestParseProjectURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		url       string
+		host      string
+		ownerType github.OwnerType
+		login     string
+		number    int
+		wantErr   bool
+	}{
+		{
+			name: "organization", url: "https://github.com/orgs/acme/projects/1",
+			host: "github.com", ownerType: github.OwnerTypeOrganization, login: "acme", number: 1,
+		},
+		{
+			name: "user", url: "https://github.com/users/octocat/projects/3",
+			host: "github.com", ownerType: github.OwnerTypeUser, login: "octocat", number: 3,
+		},
+		{
+			name: "view", url: "https://github.com/orgs/acme/projects/12/views/2?filterQuery=is%3Aopen",
+			host: "github.com", ownerType: github.OwnerTypeOrganization, login: "acme", number: 12,
+		},
+		{
+			name: "trailing slash", url: "https://github.com/users/octocat/projects/3/",
+			host: "github.com", ownerType: github.OwnerTypeUser, login: "octocat", number: 3,
+		},
+		{
+			name: "enterprise host", url: "https://GHE.example.com:443/orgs/acme/projects/1",
+			host: "ghe.example.com", ownerType: github.OwnerTypeOrganization, login: "acme", number: 1,
+		},
+		{name: "repository", url: "https://github.com/acme/roadmap/projects/1", wantErr: true},
+		{name: "no number", url: "https://github.com/orgs/acme/projects", wantErr: true},
+		{name: "invalid number", url: "https://github.com/orgs/acme/projects/one", wantErr: true},
+		{name: "zero number", url: "https://github.com/orgs/acme/projects/0", wantErr: true},
+		{name: "no scheme", url: "github.com/orgs/acme/projects/1", wantErr: true},
+		{name: "project number", url: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := cmd.ParseProjectURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("want an error, got %+v", *u)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Host != tt.host || u.OwnerType != tt.ownerType || u.Login != tt.login || u.Number != tt.number {
+				t.Errorf("want %s %v %s %d, got %+v", tt.host, tt.ownerType, tt.login, tt.number, *u)
+			}
+		})
+	}
+}
+
+func TestProjectURL(t *testing.T) {
+	t.Parallel()
+
+	sprints := "" +
+		"Title     StartDate   Duration  ID      \n" +
+		"Sprint 3  2026-10-05        14  sprint_3\n" +
+		"Sprint 4  2026-10-19        14  sprint_4\n" +
+		"Sprint 5  2026-11-02        14  sprint_5\n"
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "organization project URL argument",
+			args: []string{"list", "https://github.com/orgs/acme/projects/1", "--field", "Sprint"},
+			want: sprints,
+		},
+		{
+			name: "organization project URL flag",
+			args: []string{"list", "--project-url", "https://github.com/orgs/acme/projects/1/views/1", "--field", "Sprint"},
+			want: sprints,
+		},
+		{
+			name: "user project URL",
+			args: []string{"field-list", "https://github.com/users/octocat/projects/3"},
+			want: "" +
+				"Name  ID                 \n" +
+				"Week  PVTIF_personal_week\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := runCmd(t, newServer(t), tt.args...)
+			assertOutput(t, tt.want, got)
+		})
+	}
+}
+
+func TestProjectURLErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "invalid URL", args: []string{"list", "https://github.com/acme/roadmap", "--field", "Sprint"}},
+		{name: "foreign host", args: []string{"list", "https://ghe.example.com/orgs/acme/projects/1", "--field", "Sprint"}},
+		{name: "owner type mismatch", args: []string{"list", "https://github.com/users/acme/projects/1", "--field", "Sprint"}},
+		{
+			name: "argument and flag",
+			args: []string{
+				"list", "https://github.com/orgs/acme/projects/1", "--project-url", "https://github.com/orgs/acme/projects/1",
+				"--field", "Sprint",
+			},
+		},
+		{
+			name: "URL and project number",
+			args: []string{"list", "https://github.com/orgs/acme/projects/1", "--project", "1", "--field", "Sprint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, code := runCmdInSubprocess(t, func(*githubtest.Server) {}, tt.args...)
+			if code != 1 {
+				t.Errorf("want exit code 1, got %d", code)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/cmd"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "Yes\n", want: true},
+		{input: "n\n", want: false},
+		{input: "\n", want: false},
+		{input: "", want: false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			got, err := cmd.Confirm(strings.NewReader(test.input), &out, "Edit 2 items?")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+			assertOutput(t, "Edit 2 items? [y/N]: ", out.String())
+		})
+	}
+}
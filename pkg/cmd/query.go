@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+// compileQuery compiles a query to filter project items.
+func compileQuery(query string) (*vm.Program, error) {
+	program, err := expr.Compile(query, expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile input query: %w", err)
+	}
+	return program, nil
+}
+
+// filterItems returns the project items matching the compiled query, in order.
+// The fields of the project are given to the query even if an item has no value of them, see queryItem.
+func filterItems(
+	program *vm.Program, items []ProjectItem, fields []github.ProjectV2FieldConfiguration, ctx *queryContext,
+) ([]ProjectItem, error) {
+	matched := make([]ProjectItem, 0, len(items))
+	for _, item := range items {
+		log.Debug("Item name: " + item.Title)
+
+		output, err := expr.Run(program, queryEnv(ctx, item, fields))
+		if err != nil {
+			return nil, fmt.Errorf("failed run query: %w", err)
+		}
+		pass, ok := output.(bool)
+		if !ok {
+			return nil, errors.New("the result of query is not bool value. Please update the query")
+		}
+
+		log.Debug(fmt.Sprintf("query result: %t", pass))
+
+		if pass {
+			matched = append(matched, item)
+		}
+	}
+	return matched, nil
+}
+
+// queryItem returns the project item seen by queries, where the fields without values have empty values of their types.
+// This lets queries such as `"bug" in Item.Fields.Labels.Names` run on the items without labels.
+func queryItem(item ProjectItem, fields []github.ProjectV2FieldConfiguration) ProjectItem {
+	itemFields := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		var empty github.FieldValue
+		empty.ProjectV2ItemFieldValueCommon.Field = field
+		itemFields[field.ProjectV2FieldCommon.Name] = ConvertGitHubProjectItemField(empty)
+	}
+	for name, value := range item.Fields {
+		itemFields[name] = value
+	}
+	item.Fields = itemFields
+	return item
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type QueryCheckProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type QueryCheckOption struct {
+	ProjectSelector
+
+	Query  string
+	ItemID string
+	Date   string
+}
+
+func NewQueryCheckCmd(props *QueryCheckProps) *cobra.Command {
+	opts := new(QueryCheckOption)
+
+	// queryCheckCmd represents the query-check command.
+	queryCheckCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "query-check" + projectURLArgs,
+		Short: "Check a query of project items",
+		Long: `Check a query of project items, reporting type errors and unknown fields of the project.
+With --item, show the result of each clause of the query for the item.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					projectFlags(),
+					flags.Flag("query"),
+				),
+			)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			queryCheckRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	queryCheckCmd.Flags().SortFlags = false
+	queryCheckCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	queryCheckCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	queryCheckCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	queryCheckCmd.Flags().StringVar(&opts.Query, "query", "", "Query to check")
+	queryCheckCmd.Flags().StringVar(&opts.ItemID, "item", "", "ID of the project item to explain the query with")
+	queryCheckCmd.Flags().StringVar(&opts.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration in queries (default: today in the local time zone)")
+	_ = queryCheckCmd.MarkFlagRequired("query")
+
+	return queryCheckCmd
+}
+
+// queryCheckResult is the result of query-check.
+type queryCheckResult struct {
+	Valid    bool          `json:"valid"`
+	Problems []string      `json:"problems"`
+	Clauses  []queryClause `json:"clauses,omitempty"`
+}
+
+// queryClause is the result of a clause of a query for an item.
+// Depth is the nesting level of the clause in the logical operators, 0 for the whole query.
+type queryClause struct {
+	Clause string `json:"clause"`
+	Depth  int    `json:"depth"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+//nolint:funlen,cyclop
+func queryCheckRun(out io.Writer, props *QueryCheckProps, opts *QueryCheckOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	date, err := resolveDate(opts.Date)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	log.Debug("Retrieve project fields")
+	fields, err := client.FetchProjectFields(project.ID)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve project fields: %w", err))
+		os.Exit(1)
+	}
+
+	result := queryCheckResult{Valid: true, Problems: checkQuery(opts.Query, *fields), Clauses: nil}
+	result.Valid = len(result.Problems) == 0
+
+	if result.Valid && len(opts.ItemID) > 0 {
+		log.Debug("Retrieve project item by ID")
+		githubItem, err := client.FetchProjectItem(opts.ItemID)
+		if err != nil {
+			log.Error(fmt.Errorf("failed to retrieve a project item by item id: %w", err))
+			os.Exit(1)
+		}
+		item := ConvertGitHubProjectItem(githubItem)
+		result.Clauses, err = explainQuery(opts.Query, queryEnv(newQueryContext(client, project.ID, date), item, *fields))
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	if *props.OutputFormatJSON {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal the result: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+	} else {
+		_, _ = fmt.Fprint(out, formatQueryCheckPlain(result))
+	}
+
+	if !result.Valid {
+		os.Exit(1)
+	}
+}
+
+func formatQueryCheckPlain(result queryCheckResult) string {
+	var sb strings.Builder
+	if !result.Valid {
+		for _, problem := range result.Problems {
+			sb.WriteString(problem + "\n")
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("Query is valid.\n")
+	for _, clause := range result.Clauses {
+		value := fmt.Sprint(clause.Result)
+		if len(clause.Error) > 0 {
+			value = "error"
+		}
+		sb.WriteString(fmt.Sprintf("%-5s  %s%s", value, strings.Repeat("  ", clause.Depth), clause.Clause))
+		if len(clause.Error) > 0 {
+			sb.WriteString(" (" + clause.Error + ")")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// checkQuery returns the problems of the query: syntax and type errors, and references to unknown properties and fields.
+func checkQuery(query string, fields []github.ProjectV2FieldConfiguration) []string {
+	tree, err := parser.Parse(query)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid query: %v", err)}
+	}
+
+	fieldNames := make([]string, 0, len(fields))
+	for _, field := range fields {
+		fieldNames = append(fieldNames, field.ProjectV2FieldCommon.Name)
+	}
+	propertyNames := make([]string, 0)
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(ProjectItem{})) { //nolint:exhaustruct
+		propertyNames = append(propertyNames, field.Name)
+	}
+
+	var problems []string
+	ast.Find(tree.Node, func(node ast.Node) bool {
+		if name, ok := queryItemProperty(node); ok && !slices.Contains(propertyNames, name) {
+			problems = append(problems, unknownNameProblem("property", "Item."+name, name, propertyNames))
+		}
+		if name, ok := queryFieldName(node); ok && !slices.Contains(fieldNames, name) {
+			problems = append(problems, unknownNameProblem("field", strconv.Quote(name), name, fieldNames))
+		}
+		return false
+	})
+	if len(problems) > 0 {
+		return problems
+	}
+
+	_, err = expr.Compile(query, expr.Env(queryTypeEnv()), expr.AsBool())
+	if err != nil {
+		return []string{fmt.Sprintf("invalid query: %v", err)}
+	}
+	return nil
+}
+
+// queryTypeEnv returns the environment to type-check queries, with the types of the values given to them.
+func queryTypeEnv() map[string]any {
+	env := map[string]any{
+		"Item": ProjectItem{}, //nolint:exhaustruct
+	}
+	for _, function := range queryFunctions {
+		env[function.Name] = function.bind(nil, ProjectItem{}) //nolint:exhaustruct
+	}
+	return env
+}
+
+// queryItemProperty returns the property name if the node refers to a property of the item, as in Item.Title.
+func queryItemProperty(node ast.Node) (string, bool) {
+	member, ok := node.(*ast.MemberNode)
+	if !ok {
+		return "", false
+	}
+	identifier, ok := member.Node.(*ast.IdentifierNode)
+	if !ok || identifier.Value != "Item" {
+		return "", false
+	}
+	property, ok := member.Property.(*ast.StringNode)
+	if !ok {
+		return "", false
+	}
+	return property.Value, true
+}
+
+// queryFieldName returns the field name if the node refers to a field of the project,
+// as in Item.Fields.Sprint, Item.Fields["Sprint"] or fieldEmpty("Sprint").
+func queryFieldName(node ast.Node) (string, bool) {
+	switch node := node.(type) {
+	case *ast.MemberNode:
+		if name, ok := queryItemProperty(node.Node); !ok || name != "Fields" {
+			return "", false
+		}
+		property, ok := node.Property.(*ast.StringNode)
+		if !ok {
+			return "", false
+		}
+		return property.Value, true
+	case *ast.CallNode:
+		callee, ok := node.Callee.(*ast.IdentifierNode)
+		if !ok || len(node.Arguments) == 0 {
+			return "", false
+		}
+		for _, function := range queryFunctions {
+			if function.Name == callee.Value && function.fieldArgument {
+				argument, ok := node.Arguments[0].(*ast.StringNode)
+				if !ok {
+					return "", false
+				}
+				return argument.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// unknownNameProblem returns the problem of the unknown name, suggesting the most similar one of the known names.
+func unknownNameProblem(kind string, reference string, name string, knownNames []string) string {
+	problem := fmt.Sprintf("unknown %s %s", kind, reference)
+	if suggestion, ok := suggestName(name, knownNames); ok {
+		problem += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return problem
+}
+
+// suggestName returns the known name most similar to the name, if any is similar enough.
+func suggestName(name string, knownNames []string) (string, bool) {
+	best := ""
+	bestDistance := len(name)/3 + 1
+	for _, knownName := range knownNames {
+		if strings.EqualFold(knownName, name) {
+			return knownName, true
+		}
+		distance := editDistance(strings.ToLower(name), strings.ToLower(knownName))
+		if distance <= bestDistance && (len(best) == 0 || distance < editDistance(strings.ToLower(name), strings.ToLower(best))) {
+			best = knownName
+		}
+	}
+	return best, len(best) > 0
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a string, b string) int {
+	x, y := []rune(a), []rune(b)
+	previous := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current := make([]int, len(y)+1)
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(y)]
+}
+
+// explainQuery returns the results of the query and its clauses in the environment,
+// descending into the logical operators. All clauses are evaluated without short-circuiting.
+func explainQuery(query string, env map[string]any) ([]queryClause, error) {
+	tree, err := parser.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	var clauses []queryClause
+	var explain func(node ast.Node, depth int)
+	explain = func(node ast.Node, depth int) {
+		clause := queryClause{Clause: node.String(), Depth: depth, Result: nil, Error: ""}
+		result, err := expr.Eval(clause.Clause, env)
+		if err != nil {
+			clause.Error = firstLine(err)
+		} else {
+			clause.Result = result
+		}
+		clauses = append(clauses, clause)
+
+		switch node := node.(type) {
+		case *ast.BinaryNode:
+			if node.Operator == "&&" || node.Operator == "||" || node.Operator == "and" || node.Operator == "or" {
+				explain(node.Left, depth+1)
+				explain(node.Right, depth+1)
+			}
+		case *ast.UnaryNode:
+			if node.Operator == "!" || node.Operator == "not" {
+				explain(node.Node, depth+1)
+			}
+		}
+	}
+	explain(tree.Node, 0)
+	return clauses, nil
+}
+
+// firstLine returns the first line of the error message, without the location in the query.
+func firstLine(err error) string {
+	message, _, _ := strings.Cut(err.Error(), "\n")
+	return strings.TrimSpace(message)
+}
@@ -0,0 +1,70 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func TestQueryCheck(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"query-check", "--owner", "acme", "--project", "1", "--query", `hasLabel("bug") && Item.Fields["Linked pull requests"] != nil`)
+	assertOutput(t, "Query is valid.\n", got)
+}
+
+func TestQueryCheckExplain(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"query-check", "--owner", "acme", "--project", "1", "--item", "PVTI_1", "--date", fixtureToday,
+		"--query", `inIteration("Sprint", "current") || hasLabel("bug") && !assignedTo("hubot")`)
+	want := "" +
+		"Query is valid.\n" +
+		`true   inIteration("Sprint", "current") || (hasLabel("bug") && !assignedTo("hubot"))` + "\n" +
+		`false    inIteration("Sprint", "current")` + "\n" +
+		`true     hasLabel("bug") && !assignedTo("hubot")` + "\n" +
+		`true       hasLabel("bug")` + "\n" +
+		`true       !assignedTo("hubot")` + "\n" +
+		`false        assignedTo("hubot")` + "\n"
+	assertOutput(t, want, got)
+}
+
+func TestQueryCheckInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "unknown names",
+			query: `Item.Fields.Sprnt.Title == "Sprint 2" || fieldEmpty("status") || Item.Titel == ""`,
+			want: "" +
+				`unknown field "Sprnt", did you mean "Sprint"?` + "\n" +
+				`unknown field "status", did you mean "Status"?` + "\n" +
+				`unknown property Item.Titel, did you mean "Title"?` + "\n",
+		},
+		{
+			name:  "type error",
+			query: `Item.Number + 1`,
+			want:  "invalid query: expected bool, but got int\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, code := runCmdInSubprocess(t, func(*githubtest.Server) {},
+				"query-check", "--owner", "acme", "--project", "1", "--query", tt.query)
+			assertOutput(t, tt.want, got)
+			if code != 1 {
+				t.Errorf("want exit code 1, got %d", code)
+			}
+		})
+	}
+}
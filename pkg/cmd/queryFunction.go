@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+// queryContext is what the helper functions of queries refer to besides the item.
+type queryContext struct {
+	// date is the date to resolve the current iteration and to count days since.
+	date time.Time
+	// iterationField returns the iteration field of the project with the name.
+	iterationField func(name string) (*github.ProjectV2IterationField, error)
+}
+
+// newQueryContext returns the query context of the project, fetching the iteration fields once when they are referred to.
+func newQueryContext(client *github.Client, projectID string, date time.Time) *queryContext {
+	iterationFields := map[string]*github.ProjectV2IterationField{}
+	return &queryContext{
+		date: date,
+		iterationField: func(name string) (*github.ProjectV2IterationField, error) {
+			if field, ok := iterationFields[name]; ok {
+				return field, nil
+			}
+			field, err := client.FetchIterationFieldByName(projectID, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve an iteration field %s: %w", name, err)
+			}
+			iterationFields[name] = field
+			return field, nil
+		},
+	}
+}
+
+// queryFunction is a helper function of queries, bound to the item being matched.
+type queryFunction struct {
+	Name        string
+	Signature   string
+	Description string
+	// fieldArgument tells whether the first argument is a field name, which is checked by query-check.
+	fieldArgument bool
+	bind          func(ctx *queryContext, item ProjectItem) any
+}
+
+// queryFunctions are the helper functions of queries, in the order of the documentation.
+//
+//nolint:gochecknoglobals
+var queryFunctions = []queryFunction{
+	{
+		Name:      "inIteration",
+		Signature: "inIteration(field, iteration string) bool",
+		Description: `Whether the item is in the iteration of the field: "current", "previous", "next" or an iteration title ` +
+			`(false if there is none on the date)`,
+		fieldArgument: true,
+		bind: func(ctx *queryContext, item ProjectItem) any {
+			return func(fieldName string, iteration string) (bool, error) {
+				field, err := ctx.iterationField(fieldName)
+				if err != nil {
+					return false, err
+				}
+				var target *github.ProjectV2IterationFieldIteration
+				switch iteration {
+				case "current", "previous", "next":
+					offsets := map[string]int{"current": 0, "previous": -1, "next": 1}
+					target, err = relativeIteration(field, ctx.date, offsets[iteration])
+					if err != nil {
+						// The date falls in a gap or out of the iterations, which no item is in.
+						return false, nil //nolint:nilerr
+					}
+				default:
+					target, err = findIterationByTitle(field, iteration)
+					if err != nil {
+						return false, err
+					}
+				}
+				return itemIteration(item, fieldName).IterationID == target.ID, nil
+			}
+		},
+	},
+	{
+		Name:          "hasLabel",
+		Signature:     "hasLabel(name string) bool",
+		Description:   "Whether the item has the label, ignoring case",
+		fieldArgument: false,
+		bind: func(_ *queryContext, item ProjectItem) any {
+			return func(name string) bool {
+				for _, value := range item.Fields {
+					if labels, ok := value.(FieldLabels); ok {
+						for _, label := range labels.Names {
+							if strings.EqualFold(label, name) {
+								return true
+							}
+						}
+					}
+				}
+				return false
+			}
+		},
+	},
+	{
+		Name:          "assignedTo",
+		Signature:     "assignedTo(login string) bool",
+		Description:   "Whether the item is assigned to the user, ignoring case",
+		fieldArgument: false,
+		bind: func(_ *queryContext, item ProjectItem) any {
+			return func(login string) bool {
+				for _, value := range item.Fields {
+					if users, ok := value.(FieldUsers); ok {
+						for _, assignee := range users.Logins {
+							if strings.EqualFold(assignee, login) {
+								return true
+							}
+						}
+					}
+				}
+				return false
+			}
+		},
+	},
+	{
+		Name:          "fieldEmpty",
+		Signature:     "fieldEmpty(field string) bool",
+		Description:   "Whether the item has no value of the field",
+		fieldArgument: true,
+		bind: func(_ *queryContext, item ProjectItem) any {
+			return func(fieldName string) bool {
+				_, ok := item.Fields[fieldName]
+				return !ok
+			}
+		},
+	},
+	{
+		Name:      "daysSince",
+		Signature: "daysSince(date string) float",
+		Description: "Days from the date (YYYY-MM-DD or a timestamp such as Item.UpdatedAt) to today or --date " +
+			"(NaN, which compares false, if the date is empty)",
+		fieldArgument: false,
+		bind: func(ctx *queryContext, _ ProjectItem) any {
+			return func(date string) (float64, error) {
+				if len(date) == 0 {
+					return math.NaN(), nil
+				}
+				t, err := time.Parse(time.RFC3339, date)
+				if err != nil {
+					t, err = time.Parse(time.DateOnly, date)
+				}
+				if err != nil {
+					return 0, fmt.Errorf("invalid date %q", date)
+				}
+				day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+				return math.Round(ctx.date.Sub(day).Hours() / 24), nil //nolint:mnd
+			}
+		},
+	},
+}
+
+// queryFunctionsHelp returns the documentation of the helper functions of queries, to be included in the command help.
+func queryFunctionsHelp() string {
+	width := 0
+	for _, function := range queryFunctions {
+		width = max(width, len(function.Signature))
+	}
+	var sb strings.Builder
+	sb.WriteString("Query helper functions:\n")
+	for _, function := range queryFunctions {
+		sb.WriteString(fmt.Sprintf("  %-"+strconv.Itoa(width)+"s  %s\n", function.Signature, function.Description))
+	}
+	return sb.String()
+}
+
+// queryEnv returns the environment of queries to match the item.
+func queryEnv(ctx *queryContext, item ProjectItem, fields []github.ProjectV2FieldConfiguration) map[string]any {
+	env := map[string]any{
+		"Item": queryItem(item, fields),
+	}
+	for _, function := range queryFunctions {
+		env[function.Name] = function.bind(ctx, item)
+	}
+	return env
+}
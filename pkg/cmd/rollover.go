@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/flags"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type RolloverProps struct {
+	OutputFormatJSON *bool
+	ConfigPath       *string
+	NewClient        ClientFactory
+}
+
+type RolloverOption struct {
+	ProjectSelector
+	BatchOption
+
+	FieldName       string
+	StatusFieldName string
+	DoneOptionNames []string
+	From            string
+	To              string
+	Date            string
+	DryRun          bool
+	Limit           int
+	PageSize        int
+}
+
+const (
+	rolloverFromPrevious = "previous"
+	rolloverFromCurrent  = "current"
+	rolloverToCurrent    = "current"
+	rolloverToNext       = "next"
+)
+
+func NewRolloverCmd(props *RolloverProps) *cobra.Command {
+	opts := new(RolloverOption)
+
+	// rolloverCmd represents the rollover command.
+	rolloverCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "rollover" + projectURLArgs,
+		Short: "Move unfinished project items to the following iteration",
+		Long: `Move unfinished project items to the following iteration.
+Project items in the source iteration whose status is not one of the done options
+are moved to the destination iteration. Archived items are left as they are.
+The moves are journaled, so that they can be reverted by undo.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := validateProjectFlags(cmd, args, *props.ConfigPath, "", &opts.ProjectSelector,
+				flags.And(
+					flags.Flag("field"),
+					projectFlags(),
+				),
+			)
+			if err != nil {
+				return err
+			}
+			if opts.From != rolloverFromPrevious && opts.From != rolloverFromCurrent {
+				return fmt.Errorf("flags: from must be %q or %q: %s", rolloverFromPrevious, rolloverFromCurrent, opts.From)
+			}
+			if opts.To != rolloverToCurrent && opts.To != rolloverToNext {
+				return fmt.Errorf("flags: to must be %q or %q: %s", rolloverToCurrent, rolloverToNext, opts.To)
+			}
+			if opts.From == opts.To {
+				return fmt.Errorf("flags: from and to must be different iterations: %s", opts.From)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			rolloverRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	rolloverCmd.Flags().SortFlags = false
+	rolloverCmd.Flags().StringVar(&opts.FieldName, "field", "", "Iteration field name")
+	rolloverCmd.Flags().IntVar(&opts.ProjectNumber, "project", 0, "Project number")
+	rolloverCmd.Flags().StringVar(&opts.ProjectOwner, "owner", "", "User/Organization login name")
+	rolloverCmd.Flags().StringVar(&opts.ProjectURL, "project-url", "", projectURLUsage)
+	rolloverCmd.Flags().StringVar(&opts.StatusFieldName, "status-field", "Status", "Single select field name of the item status")
+	rolloverCmd.Flags().StringSliceVar(&opts.DoneOptionNames, "done", []string{"Done"}, "Status option names of the finished items")
+	rolloverCmd.Flags().StringVar(&opts.From, "from", rolloverFromPrevious,
+		fmt.Sprintf("Iteration to move the items from: %q or %q", rolloverFromPrevious, rolloverFromCurrent))
+	rolloverCmd.Flags().StringVar(&opts.To, "to", rolloverToCurrent,
+		fmt.Sprintf("Iteration to move the items to: %q or %q", rolloverToCurrent, rolloverToNext))
+	rolloverCmd.Flags().StringVar(&opts.Date, "date", "",
+		"Date (YYYY-MM-DD) to resolve the current iteration (default: today in the local time zone)")
+	rolloverCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "DryRun mode")
+	rolloverCmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum number of project items to scan (0 for no limit)")
+	rolloverCmd.Flags().IntVar(&opts.PageSize, "page-size", github.DefaultItemsPageSize, "Number of project items to fetch per request")
+	addBatchFlags(rolloverCmd, &opts.BatchOption)
+
+	return rolloverCmd
+}
+
+type rolloverItem struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type rolloverResult struct {
+	From    JSONFormattedIteration `json:"from"`
+	To      JSONFormattedIteration `json:"to"`
+	Items   []rolloverItem         `json:"items"`
+	DryRun  bool                   `json:"dryRun"`
+	Summary *itemsEditSummary      `json:"summary,omitempty"`
+}
+
+//nolint:funlen,cyclop
+func rolloverRun(out io.Writer, props *RolloverProps, opts *RolloverOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	err = opts.BatchOption.validate()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	project, err := retrieveProject(client, &opts.ProjectSelector)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	log.Debug("Retrieve an iteration field by field name and project")
+	iterationField, err := client.FetchIterationFieldByName(project.ID, opts.FieldName)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve an iteration by field name and project: %w", err))
+		os.Exit(1)
+	}
+
+	from, to, err := rolloverIterations(iterationField, opts)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	log.Debug(fmt.Sprintf("Roll over from %s to %s", from.Title, to.Title))
+
+	log.Debug("Retrieve project items")
+	githubItems, err := client.FetchProjectItems(project.ID, opts.PageSize, opts.Limit)
+	if err != nil {
+		log.Error(fmt.Errorf("failed to retrieve a project items by item id: %w", err))
+		os.Exit(1)
+	}
+	log.Debug(fmt.Sprintf("Scanned items: %d", len(*githubItems)))
+
+	// Each unfinished item is moved from the source iteration to the destination one.
+	var plans []itemPlan
+	statuses := map[string]string{}
+	for _, githubItem := range *githubItems {
+		item := ConvertGitHubProjectItem(&githubItem)
+		if item.IsArchived {
+			continue
+		}
+		iteration, ok := item.Fields[opts.FieldName].(FieldIteration)
+		if !ok || iteration.IterationID != from.ID {
+			continue
+		}
+		status := ""
+		if s, ok := item.Fields[opts.StatusFieldName].(FieldSingleSelect); ok {
+			status = s.Name
+		}
+		if slices.Contains(opts.DoneOptionNames, status) {
+			log.Debug("Finished item. Skip: " + item.Title)
+			continue
+		}
+		statuses[item.ID] = status
+
+		result := itemsEditResult{
+			ID:                     item.ID,
+			Title:                  item.Title,
+			PreviousIterationID:    from.ID,
+			PreviousIterationTitle: from.Title,
+			NewIterationID:         to.ID,
+			NewIterationTitle:      to.Title,
+			Skipped:                false,
+			DryRun:                 opts.DryRun,
+			Error:                  "",
+			err:                    nil,
+			change:                 &itemsEditChange{ItemID: item.ID, FromIterationID: from.ID, ToIterationID: to.ID},
+		}
+		var update *github.IterationFieldUpdate
+		if !opts.DryRun {
+			update = &github.IterationFieldUpdate{ItemID: item.ID, IterationID: to.ID}
+		}
+		plans = append(plans, itemPlan{result: result, update: update})
+	}
+
+	var journal *journalWriter
+	if !opts.DryRun {
+		journal, err = openJournal(opts.Journal)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	result := rolloverResult{
+		From:    formatIterationJSON(from),
+		To:      formatIterationJSON(to),
+		Items:   []rolloverItem{},
+		DryRun:  opts.DryRun,
+		Summary: nil,
+	}
+	_, summary, stopped := editItems(client, project.ID, iterationField.ID, plans, &opts.BatchOption, journal,
+		func(itemResult itemsEditResult) {
+			result.Items = append(result.Items, rolloverItem{
+				ID:     itemResult.ID,
+				Title:  itemResult.Title,
+				Status: statuses[itemResult.ID],
+				Error:  itemResult.Error,
+			})
+		})
+	if journal != nil {
+		err := journal.Close()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	}
+	summary.Scanned = len(*githubItems)
+	if opts.ContinueOnError {
+		result.Summary = &summary
+	}
+
+	// The items already moved are reported even if it stopped at a failure.
+	if *props.OutputFormatJSON {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal result: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprint(out, string(bytes))
+	} else {
+		_, _ = fmt.Fprint(out, formatRolloverPlain(&result))
+	}
+	if stopped {
+		os.Exit(1)
+	}
+	if summary.Failed > 0 {
+		os.Exit(exitCodeItemsFailed)
+	}
+}
+
+func rolloverIterations(
+	field *github.ProjectV2IterationField, opts *RolloverOption,
+) (*github.ProjectV2IterationFieldIteration, *github.ProjectV2IterationFieldIteration, error) {
+	date, err := resolveDate(opts.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var from, to *github.ProjectV2IterationFieldIteration
+	if opts.From == rolloverFromPrevious {
+		from, err = relativeIteration(field, date, -1)
+	} else {
+		from, err = currentIteration(field, date)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.To == rolloverToCurrent {
+		to, err = currentIteration(field, date)
+	} else {
+		to, err = relativeIteration(field, date, 1)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func formatIterationJSON(iteration *github.ProjectV2IterationFieldIteration) JSONFormattedIteration {
+	return JSONFormattedIteration{
+		ID:        iteration.ID,
+		Title:     iteration.Title,
+		StartDate: iteration.StartDate,
+		Duration:  iteration.Duration,
+	}
+}
+
+func formatRolloverPlain(result *rolloverResult) string {
+	idLen, titleLen, statusLen := len("ID"), len("Title"), len("Status")
+	for _, item := range result.Items {
+		idLen = max(idLen, len(item.ID))
+		titleLen = max(titleLen, len(item.Title))
+		statusLen = max(statusLen, len(item.Status))
+	}
+
+	var sb strings.Builder
+	moved := 0
+	if len(result.Items) > 0 {
+		format := "%-" + strconv.Itoa(idLen) + "s  %-" + strconv.Itoa(titleLen) + "s  %-" + strconv.Itoa(statusLen) + "s  %s\n"
+		sb.WriteString(fmt.Sprintf(format, "ID", "Title", "Status", "Result"))
+		for _, item := range result.Items {
+			action := "Moved"
+			switch {
+			case len(item.Error) > 0:
+				action = "Failed. " + item.Error
+			case result.DryRun:
+				action = "DryRun"
+			}
+			if len(item.Error) == 0 {
+				moved++
+			}
+			sb.WriteString(fmt.Sprintf(format, item.ID, item.Title, item.Status, action))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%d items rolled over from %s to %s.\n", moved, result.From.Title, result.To.Title))
+	if result.Summary != nil {
+		sb.WriteString(formatItemsEditSummary(*result.Summary, result.DryRun))
+	}
+	return sb.String()
+}
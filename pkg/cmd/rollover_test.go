@@ -0,0 +1,115 @@
+package cmd_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func TestRollover(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server, "rollover", "--owner", "acme", "--project", "1", "--field", "Sprint", "--date", fixtureToday)
+	want := "" +
+		"ID      Title          Status       Result\n" +
+		"PVTI_1  Fix login bug  In progress  Moved\n" +
+		"1 items rolled over from Sprint 2 to Sprint 3.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_2", "Sprint", "sprint_2")
+}
+
+func TestRolloverToNext(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	got := runCmd(t, server,
+		"rollover", "--owner", "acme", "--project", "1", "--field", "Sprint",
+		"--from", "current", "--to", "next", "--date", fixtureToday, "--done", "Done,Todo", "--dry-run", "--json")
+	want := `{
+  "from": {
+    "id": "sprint_3",
+    "title": "Sprint 3",
+    "startDate": "2026-10-05",
+    "duration": 14
+  },
+  "to": {
+    "id": "sprint_4",
+    "title": "Sprint 4",
+    "startDate": "2026-10-19",
+    "duration": 14
+  },
+  "items": [
+    {
+      "id": "PVTI_3",
+      "title": "Refactor API client",
+      "status": "In progress"
+    }
+  ],
+  "dryRun": true
+}`
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "sprint_3")
+}
+
+func TestRolloverContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	journal := subprocessTempFile(t, "journal.jsonl")
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) { server.FailItem("PVTI_1") },
+		"rollover", "--owner", "acme", "--project", "1", "--field", "Sprint", "--date", fixtureToday, "--done", "Closed",
+		"--batch-size", "2", "--continue-on-error", "--journal", journal)
+	want := "" +
+		"ID      Title          Status       Result\n" +
+		"PVTI_1  Fix login bug  In progress  Failed. failed to update the iteration field: " +
+		"GraphQL: something went wrong while updating the item 'PVTI_1' (m0)\n" +
+		"PVTI_2  Add dark mode  Done         Moved\n" +
+		"1 items rolled over from Sprint 2 to Sprint 3.\n" +
+		"1 updated, 0 skipped, 1 failed.\n"
+	assertOutput(t, want, got)
+	if code != 2 {
+		t.Errorf("want exit code 2, got %d", code)
+	}
+
+	bytes, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(bytes), `"itemId":"PVTI_2"`); got != 1 || strings.Contains(string(bytes), "PVTI_1") {
+		t.Errorf("wrong journal: %s", bytes)
+	}
+}
+
+func TestRolloverStopOnError(t *testing.T) {
+	t.Parallel()
+
+	got, code := runCmdInSubprocess(t, func(server *githubtest.Server) { server.FailItem("PVTI_2") },
+		"rollover", "--owner", "acme", "--project", "1", "--field", "Sprint", "--date", fixtureToday, "--done", "Closed")
+	want := "" +
+		"ID      Title          Status       Result\n" +
+		"PVTI_1  Fix login bug  In progress  Moved\n" +
+		"PVTI_2  Add dark mode  Done         Failed. failed to update the iteration field: " +
+		"GraphQL: something went wrong while updating the item 'PVTI_2' (m0)\n" +
+		"1 items rolled over from Sprint 2 to Sprint 3.\n"
+	assertOutput(t, want, got)
+	if code != 1 {
+		t.Errorf("want exit code 1, got %d", code)
+	}
+}
+
+//nolint:paralleltest
+func TestRolloverUndo(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	server := newServer(t)
+	runCmd(t, server, "rollover", "--owner", "acme", "--project", "1", "--field", "Sprint", "--date", fixtureToday)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_3")
+
+	runCmd(t, server, "undo")
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_2")
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/config"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
 	"github.com/tasshi-me/gh-iteration/pkg/log"
 )
 
@@ -12,9 +14,18 @@ type RootOptions struct {
 	Trace            bool
 	LogFormatJSON    bool
 	OutputFormatJSON bool
+	ConfigPath       string
 }
 
+// ClientFactory creates the GitHub client used by the subcommands.
+type ClientFactory func() (*github.Client, error)
+
 func NewRootCmd() *cobra.Command {
+	return NewRootCmdWithClientFactory(github.NewDefaultClient)
+}
+
+// NewRootCmdWithClientFactory creates the root command whose subcommands use the clients created by newClient.
+func NewRootCmdWithClientFactory(newClient ClientFactory) *cobra.Command {
 	opts := new(RootOptions)
 
 	// rootCmd represents the base command when called without any subcommands.
@@ -30,6 +41,12 @@ This command enables you to retrieve iteration field information or update an it
 To run commands, your token should have 'project' scope.
 To verify your token scope, run 'gh auth status'.
 To add the 'project' scope, run 'gh auth refresh -s project'.
+
+The --owner, --project and --field flags not given default to the environment variables
+GH_ITERATION_OWNER, GH_ITERATION_PROJECT and GH_ITERATION_FIELD, to the config file (see 'gh iteration config'),
+and to the owner of the current repository, in this order.
+In place of --owner and --project, the project can be given by its URL with --project-url or as the argument,
+such as https://github.com/orgs/<org>/projects/<number> or https://github.com/users/<user>/projects/<number>.
 `,
 		Args: cobra.NoArgs,
 		PersistentPreRun: func(_ *cobra.Command, _ []string) {
@@ -53,24 +70,84 @@ To add the 'project' scope, run 'gh auth refresh -s project'.
 	rootCmd.Flag("trace").Hidden = true
 	rootCmd.PersistentFlags().BoolVar(&opts.LogFormatJSON, "log-json", false, "Output log in JSON")
 	rootCmd.PersistentFlags().BoolVar(&opts.OutputFormatJSON, "json", false, "Output result in JSON")
+	rootCmd.PersistentFlags().StringVar(&opts.ConfigPath, "config", "",
+		"Config file (default: ~/.config/gh-iteration/config.yml and "+config.LocalFileName+" of the repository)")
 
 	rootCmd.AddCommand(NewListCmd(&ListProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
 	}))
 	rootCmd.AddCommand(NewFieldListCmd(&FieldListProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
 	}))
 	rootCmd.AddCommand(NewFieldViewCmd(&FieldViewProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewFieldCreateCmd(&FieldCreateProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewIterationCreateCmd(&IterationCreateProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewIterationEditCmd(&IterationEditProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewIterationDeleteCmd(&IterationDeleteProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
 	}))
 	rootCmd.AddCommand(NewItemViewCmd(&ItemViewProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		NewClient:        newClient,
 	}))
 	rootCmd.AddCommand(NewItemEditCmd(&ItemEditProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewItemsListCmd(&ItemsListProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewQueryCheckCmd(&QueryCheckProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
 	}))
 	rootCmd.AddCommand(NewItemsEditCmd(&ItemsEditProps{
 		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewApplyCmd(&ApplyProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewUndoCmd(&UndoProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewRolloverCmd(&RolloverProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
+		NewClient:        newClient,
+	}))
+	rootCmd.AddCommand(NewConfigCmd(&ConfigProps{
+		OutputFormatJSON: &opts.OutputFormatJSON,
+		ConfigPath:       &opts.ConfigPath,
 	}))
 
 	return rootCmd
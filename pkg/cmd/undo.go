@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+type UndoProps struct {
+	OutputFormatJSON *bool
+	NewClient        ClientFactory
+}
+
+type UndoOption struct {
+	Journal string
+	DryRun  bool
+}
+
+func NewUndoCmd(props *UndoProps) *cobra.Command {
+	opts := new(UndoOption)
+
+	// undoCmd represents the undo command.
+	undoCmd := &cobra.Command{ //nolint:exhaustruct
+		Use:   "undo",
+		Short: "Restore iteration field values recorded in a journal",
+		Long: `Restore iteration field values recorded in a journal written by items-edit, apply or rollover.
+By default, the journal of the last run not undone yet is used, and marked as undone afterward.
+The changes are reverted from the last one, and fields that were empty are cleared.
+Items whose values have changed since are skipped.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			undoRun(cmd.OutOrStdout(), props, opts)
+		},
+	}
+
+	undoCmd.Flags().SortFlags = false
+	undoCmd.Flags().StringVar(&opts.Journal, "journal", "",
+		"Journal file written by items-edit or apply (default: the last run in "+journalDirHelp+")")
+	undoCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "DryRun mode")
+
+	return undoCmd
+}
+
+//nolint:funlen,cyclop
+func undoRun(out io.Writer, props *UndoProps, opts *UndoOption) {
+	client, err := props.NewClient()
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	path := opts.Journal
+	if len(path) == 0 {
+		path, err = lastRunJournal()
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		log.Debug("Journal: " + path)
+	}
+	entries, err := readJournal(path)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	// values holds the current iteration IDs of the items by the item ID and the field ID,
+	// updated as the changes are reverted, so that the earlier changes of an item are checked against them.
+	values, err := fetchJournalValues(client, entries)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+
+	reverted, skipped := 0, 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		key := journalValueKey{ItemID: entry.ItemID, FieldID: entry.FieldID}
+		result := undoResult{
+			ItemID:      entry.ItemID,
+			FieldID:     entry.FieldID,
+			IterationID: entry.PreviousIterationID,
+			DryRun:      opts.DryRun,
+			Skipped:     false,
+			Reason:      "",
+		}
+
+		value, ok := values[key]
+		switch {
+		case !ok:
+			result.Skipped = true
+			result.Reason = "the item is not found"
+		case value != entry.NewIterationID:
+			result.Skipped = true
+			result.Reason = fmt.Sprintf("the value has changed from %q to %q since", entry.NewIterationID, value)
+		}
+		if result.Skipped {
+			skipped++
+			printUndoResult(out, *props.OutputFormatJSON, result)
+			continue
+		}
+
+		if !opts.DryRun {
+			update := github.IterationFieldUpdate{ItemID: entry.ItemID, IterationID: entry.PreviousIterationID}
+			errs, err := client.UpdateIterationFields(entry.ProjectID, entry.FieldID, []github.IterationFieldUpdate{update}, 1)
+			if err == nil {
+				err = errs[0]
+			}
+			if err != nil {
+				log.Error(fmt.Errorf("failed to restore the iteration field of %s: %w", entry.ItemID, err))
+				os.Exit(1)
+			}
+		}
+		values[key] = entry.PreviousIterationID
+		reverted++
+
+		printUndoResult(out, *props.OutputFormatJSON, result)
+	}
+
+	if len(opts.Journal) == 0 && !opts.DryRun {
+		err := os.Rename(path, path+undoneJournalExt)
+		if err != nil {
+			log.Error(fmt.Errorf("failed to mark the journal as undone: %w", err))
+			os.Exit(1)
+		}
+	}
+
+	if *props.OutputFormatJSON {
+		return
+	}
+	if opts.DryRun {
+		_, _ = fmt.Fprintf(out, "%d changes to revert", reverted)
+	} else {
+		_, _ = fmt.Fprintf(out, "%d changes reverted", reverted)
+	}
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(out, ", %d skipped", skipped)
+	}
+	_, _ = fmt.Fprintln(out, ".")
+}
+
+// journalValueKey is the key of the iteration field value of a project item.
+type journalValueKey struct {
+	ItemID  string
+	FieldID string
+}
+
+// fetchJournalValues returns the current iteration field values of the items in the journal entries.
+// The items not found in their projects are left out.
+func fetchJournalValues(client *github.Client, entries []journalEntry) (map[journalValueKey]string, error) {
+	items := map[string]map[string]ProjectItem{}
+	fieldNames := map[string]string{}
+	values := map[journalValueKey]string{}
+	for _, entry := range entries {
+		if _, ok := items[entry.ProjectID]; !ok {
+			log.Debug("Retrieve project items")
+			githubItems, err := client.FetchProjectItems(entry.ProjectID, github.DefaultItemsPageSize, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve project items: %w", err)
+			}
+			items[entry.ProjectID] = map[string]ProjectItem{}
+			for _, githubItem := range *githubItems {
+				item := ConvertGitHubProjectItem(&githubItem)
+				items[entry.ProjectID][item.ID] = item
+			}
+		}
+		if _, ok := fieldNames[entry.FieldID]; !ok {
+			log.Debug("Retrieve an iteration field by field ID")
+			field, err := client.FetchIterationFieldByID(entry.FieldID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve an iteration field by field ID: %w", err)
+			}
+			fieldNames[entry.FieldID] = field.Name
+		}
+
+		item, ok := items[entry.ProjectID][entry.ItemID]
+		if ok {
+			values[journalValueKey{ItemID: entry.ItemID, FieldID: entry.FieldID}] = itemIteration(item, fieldNames[entry.FieldID]).IterationID
+		}
+	}
+	return values, nil
+}
+
+func printUndoResult(out io.Writer, outputFormatJSON bool, result undoResult) {
+	if outputFormatJSON {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Error(fmt.Errorf("failed to marshal result: %w", err))
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(out, string(bytes))
+		return
+	}
+
+	switch {
+	case result.Skipped:
+		_, _ = fmt.Fprintf(out, "%s => Skipped. %s.\n", result.ItemID, result.Reason)
+	case result.DryRun:
+		_, _ = fmt.Fprintf(out, "%s => DryRun.\n", result.ItemID)
+	case len(result.IterationID) == 0:
+		_, _ = fmt.Fprintf(out, "%s => Cleared.\n", result.ItemID)
+	default:
+		_, _ = fmt.Fprintf(out, "%s => Restored %s.\n", result.ItemID, result.IterationID)
+	}
+}
+
+type undoResult struct {
+	ItemID      string `json:"itemId"`
+	FieldID     string `json:"fieldId"`
+	IterationID string `json:"iterationId"`
+	DryRun      bool   `json:"dryRun"`
+	Skipped     bool   `json:"skipped"`
+	Reason      string `json:"reason,omitempty"`
+}
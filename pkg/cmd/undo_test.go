@@ -0,0 +1,100 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	journal := filepath.Join(t.TempDir(), "journal.jsonl")
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--current",
+		"--date", fixtureToday, "--journal", journal)
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", `Item.ID == "PVTI_1"`, "--clear",
+		"--journal", journal)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", nil)
+
+	got := runCmd(t, server, "undo", "--journal", journal)
+	want := "" +
+		"PVTI_1 => Restored sprint_3.\n" +
+		"PVTI_6 => Cleared.\n" +
+		"PVTI_1 => Restored sprint_2.\n" +
+		"3 changes reverted.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_2")
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "sprint_3")
+	assertFieldValue(t, server, "PVTI_6", "Sprint", nil)
+}
+
+func TestUndoDryRun(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	journal := filepath.Join(t.TempDir(), "journal.jsonl")
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--clear",
+		"--journal", journal)
+
+	got := runCmd(t, server, "undo", "--journal", journal, "--dry-run")
+	want := "" +
+		"PVTI_3 => DryRun.\n" +
+		"PVTI_1 => DryRun.\n" +
+		"2 changes to revert.\n"
+	assertOutput(t, want, got)
+
+	assertFieldValue(t, server, "PVTI_1", "Sprint", nil)
+}
+
+//nolint:paralleltest
+func TestUndoLastRun(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	server := newServer(t)
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--current",
+		"--date", fixtureToday)
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", `Item.ID == "PVTI_1"`, "--clear")
+
+	// Each undo reverts the last run not undone yet.
+	got := runCmd(t, server, "undo")
+	want := "" +
+		"PVTI_1 => Restored sprint_3.\n" +
+		"1 changes reverted.\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_3")
+
+	got = runCmd(t, server, "undo")
+	want = "" +
+		"PVTI_6 => Cleared.\n" +
+		"PVTI_1 => Restored sprint_2.\n" +
+		"2 changes reverted.\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_2")
+	assertFieldValue(t, server, "PVTI_6", "Sprint", nil)
+}
+
+func TestUndoDrift(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	journal := filepath.Join(t.TempDir(), "journal.jsonl")
+	runCmd(t, server,
+		"items-edit", "--owner", "acme", "--project", "1", "--field", "Sprint", "--query", queryInProgress, "--clear",
+		"--journal", journal)
+	runCmd(t, server, "item-edit", "--id", "PVTI_1", "--field", "Sprint", "--iteration", "Sprint 4")
+
+	got := runCmd(t, server, "undo", "--journal", journal)
+	want := "" +
+		"PVTI_3 => Restored sprint_3.\n" +
+		"PVTI_1 => Skipped. the value has changed from \"\" to \"sprint_4\" since.\n" +
+		"1 changes reverted, 1 skipped.\n"
+	assertOutput(t, want, got)
+	assertFieldValue(t, server, "PVTI_1", "Sprint", "sprint_4")
+	assertFieldValue(t, server, "PVTI_3", "Sprint", "sprint_3")
+}
@@ -0,0 +1,305 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalFileName is the name of the repository-local config file, placed at the root of the repository.
+const LocalFileName = ".gh-iteration.yml"
+
+// Config is the content of a config file.
+type Config struct {
+	Owner   string            `yaml:"owner,omitempty"`
+	Project int               `yaml:"project,omitempty"`
+	Field   string            `yaml:"field,omitempty"`
+	Queries map[string]string `yaml:"queries,omitempty"`
+	Presets map[string]Preset `yaml:"presets,omitempty"`
+}
+
+// Preset is a named set of option values. Query is a query or the name of a saved query.
+type Preset struct {
+	Owner   string `yaml:"owner,omitempty"`
+	Project int    `yaml:"project,omitempty"`
+	Field   string `yaml:"field,omitempty"`
+	Query   string `yaml:"query,omitempty"`
+}
+
+// Entry is a key and a value of a config, such as "presets.sprint.query".
+type Entry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+var (
+	ErrUnknownKey    = errors.New("unknown config key")
+	ErrUnknownPreset = errors.New("unknown preset")
+)
+
+// GlobalPath returns the path of the config file of the user: $XDG_CONFIG_HOME/gh-iteration/config.yml,
+// or ~/.config/gh-iteration/config.yml if XDG_CONFIG_HOME is not set.
+func GlobalPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if len(dir) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gh-iteration", "config.yml"), nil
+}
+
+// LocalPath returns the path of the config file at the root of the git repository containing the current directory.
+// It returns false if the current directory is not in a git repository.
+func LocalPath() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return filepath.Join(dir, LocalFileName), true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Load reads the config file at the path. If the path is empty, it reads the global config file
+// and the local one of the repository, whose values take precedence. Missing files are empty configs.
+func Load(path string) (*Config, error) {
+	if len(path) > 0 {
+		return ReadFile(path)
+	}
+
+	globalPath, err := GlobalPath()
+	if err != nil {
+		return nil, err
+	}
+	config, err := ReadFile(globalPath)
+	if err != nil {
+		return nil, err
+	}
+	if localPath, ok := LocalPath(); ok {
+		local, err := ReadFile(localPath)
+		if err != nil {
+			return nil, err
+		}
+		config.merge(local)
+	}
+	return config, nil
+}
+
+// ReadFile reads the config file, or returns an empty config if it does not exist.
+func ReadFile(path string) (*Config, error) {
+	config := new(Config)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %w", err)
+	}
+	err = yaml.Unmarshal(bytes, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// WriteFile writes the config to the file, creating its directory if needed.
+func WriteFile(path string, config *Config) error {
+	bytes, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the config: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to create the config directory: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0o600) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to write the config file: %w", err)
+	}
+	return nil
+}
+
+// merge overwrites the config with the values set in the other config.
+func (c *Config) merge(other *Config) {
+	for _, entry := range other.Entries() {
+		_ = c.Set(entry.Key, entry.Value)
+	}
+}
+
+// Entries returns the entries of the values set in the config, sorted by key.
+func (c *Config) Entries() []Entry {
+	var entries []Entry
+	add := func(key string, value string) {
+		if len(value) > 0 {
+			entries = append(entries, Entry{Key: key, Value: value})
+		}
+	}
+	add("owner", c.Owner)
+	add("project", formatProject(c.Project))
+	add("field", c.Field)
+	for name, query := range c.Queries {
+		add("queries."+name, query)
+	}
+	for name, preset := range c.Presets {
+		add("presets."+name+".owner", preset.Owner)
+		add("presets."+name+".project", formatProject(preset.Project))
+		add("presets."+name+".field", preset.Field)
+		add("presets."+name+".query", preset.Query)
+	}
+	slices.SortFunc(entries, func(a Entry, b Entry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return entries
+}
+
+// Get returns the value of the key, and whether it is set.
+func (c *Config) Get(key string) (string, bool) {
+	for _, entry := range c.Entries() {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the key, or unsets it if the value is empty. The keys are owner, project, field, queries.<name>,
+// and presets.<name>.owner, presets.<name>.project, presets.<name>.field, presets.<name>.query.
+//
+//nolint:cyclop
+func (c *Config) Set(key string, value string) error {
+	section, rest, _ := strings.Cut(key, ".")
+	switch section {
+	case "owner", "project", "field":
+		if len(rest) > 0 {
+			break
+		}
+		return setOption(key, section, value, &c.Owner, &c.Project, &c.Field)
+	case "queries":
+		if len(rest) == 0 {
+			break
+		}
+		if len(value) == 0 {
+			delete(c.Queries, rest)
+			return nil
+		}
+		if c.Queries == nil {
+			c.Queries = map[string]string{}
+		}
+		c.Queries[rest] = value
+		return nil
+	case "presets":
+		name, option, ok := cutLast(rest, ".")
+		if !ok || len(name) == 0 {
+			break
+		}
+		if c.Presets == nil {
+			c.Presets = map[string]Preset{}
+		}
+		preset := c.Presets[name]
+		if option == "query" {
+			preset.Query = value
+		} else if err := setOption(key, option, value, &preset.Owner, &preset.Project, &preset.Field); err != nil {
+			return err
+		}
+		if preset == (Preset{}) { //nolint:exhaustruct
+			delete(c.Presets, name)
+		} else {
+			c.Presets[name] = preset
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownKey, key)
+}
+
+// Defaults returns the default option values set in the config.
+func (c *Config) Defaults() map[string]string {
+	return options(c.Owner, c.Project, c.Field, "")
+}
+
+// Preset returns the option values set in the preset. Its query is resolved if it is the name of a saved query,
+// and a saved query is a preset of the query.
+func (c *Config) Preset(name string) (map[string]string, error) {
+	preset, ok := c.Presets[name]
+	if !ok {
+		query, ok := c.Queries[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPreset, name)
+		}
+		preset = Preset{Owner: "", Project: 0, Field: "", Query: query}
+	}
+	if query, ok := c.Queries[preset.Query]; ok {
+		preset.Query = query
+	}
+	return options(preset.Owner, preset.Project, preset.Field, preset.Query), nil
+}
+
+// options returns the option values by the flag names, without the values not set.
+func options(owner string, project int, field string, query string) map[string]string {
+	values := map[string]string{}
+	for option, value := range map[string]string{
+		"owner":   owner,
+		"project": formatProject(project),
+		"field":   field,
+		"query":   query,
+	} {
+		if len(value) > 0 {
+			values[option] = value
+		}
+	}
+	return values
+}
+
+func setOption(key string, option string, value string, owner *string, project *int, field *string) error {
+	switch option {
+	case "owner":
+		*owner = value
+	case "project":
+		if len(value) == 0 {
+			*project = 0
+			return nil
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid project number of %s: %w", key, err)
+		}
+		*project = number
+	case "field":
+		*field = value
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	return nil
+}
+
+func formatProject(project int) string {
+	if project == 0 {
+		return ""
+	}
+	return strconv.Itoa(project)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s string, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/config"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	var conf config.Config
+	for _, entry := range []config.Entry{
+		{Key: "owner", Value: "acme"},
+		{Key: "project", Value: "1"},
+		{Key: "queries.in-progress", Value: `Item.Fields.Status.Name == "In progress"`},
+		{Key: "presets.sprint.field", Value: "Sprint"},
+		{Key: "presets.sprint.query", Value: "in-progress"},
+		{Key: "presets.v2.project", Value: "2"},
+	} {
+		err := conf.Set(entry.Key, entry.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := conf.Set("presets.v2.project", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []config.Entry{
+		{Key: "owner", Value: "acme"},
+		{Key: "presets.sprint.field", Value: "Sprint"},
+		{Key: "presets.sprint.query", Value: "in-progress"},
+		{Key: "project", Value: "1"},
+		{Key: "queries.in-progress", Value: `Item.Fields.Status.Name == "In progress"`},
+	}
+	if got := conf.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong entries\nwant: %v\ngot:  %v", want, got)
+	}
+	if got, ok := conf.Get("presets.sprint.field"); !ok || got != "Sprint" {
+		t.Errorf("wrong value of presets.sprint.field: %q, %v", got, ok)
+	}
+	if _, ok := conf.Get("field"); ok {
+		t.Error("field must not be set")
+	}
+}
+
+func TestSetInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{key: "owners", value: "acme"},
+		{key: "owner.name", value: "acme"},
+		{key: "queries", value: "true"},
+		{key: "presets.sprint", value: "true"},
+		{key: "presets.sprint.columns", value: "id"},
+		{key: "project", value: "one"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+
+			var conf config.Config
+			if err := conf.Set(tt.key, tt.value); err == nil {
+				t.Errorf("want an error to set %s", tt.key)
+			}
+		})
+	}
+}
+
+func TestPreset(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{
+		Owner:   "acme",
+		Project: 1,
+		Field:   "Sprint",
+		Queries: map[string]string{"todo": `Item.Fields.Status.Name == "Todo"`},
+		Presets: map[string]config.Preset{
+			"infra": {Owner: "", Project: 2, Field: "", Query: "todo"},
+			"bugs":  {Owner: "", Project: 0, Field: "Release", Query: `hasLabel("bug")`},
+		},
+	}
+
+	want := map[string]string{"owner": "acme", "project": "1", "field": "Sprint"}
+	if got := conf.Defaults(); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong defaults\nwant: %v\ngot:  %v", want, got)
+	}
+
+	tests := []struct {
+		preset string
+		want   map[string]string
+	}{
+		{preset: "infra", want: map[string]string{"project": "2", "query": conf.Queries["todo"]}},
+		{preset: "bugs", want: map[string]string{"field": "Release", "query": `hasLabel("bug")`}},
+		{preset: "todo", want: map[string]string{"query": conf.Queries["todo"]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := conf.Preset(tt.preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong options\nwant: %v\ngot:  %v", tt.want, got)
+			}
+		})
+	}
+
+	_, err := conf.Preset("unknown")
+	if !errors.Is(err, config.ErrUnknownPreset) {
+		t.Errorf("want ErrUnknownPreset, got %v", err)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gh-iteration", "config.yml")
+	conf, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.Entries()) > 0 {
+		t.Errorf("want an empty config of a missing file, got %v", conf.Entries())
+	}
+
+	err = conf.Set("presets.sprint.query", `Item.Fields.Status.Name == "Todo"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.WriteFile(path, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, conf) {
+		t.Errorf("wrong config\nwant: %v\ngot:  %v", conf, got)
+	}
+}
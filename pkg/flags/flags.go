@@ -1,7 +1,12 @@
 package flags
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/tasshi-me/gh-iteration/pkg/log"
 )
 
 func Flag(flag string) Node {
@@ -16,18 +21,71 @@ func And(nodes ...Node) Node {
 	return Node{Flag: "", Or: nil, And: nodes}
 }
 
+// Source supplies the values of the flags not given on the command line, such as environment variables.
+type Source struct {
+	Name   string
+	Lookup func(flag string) (string, bool)
+}
+
 type Validator struct {
-	node *Node
+	node    *Node
+	sources []Source
+}
+
+// NewValidator returns a validator of the flags, where a flag is also set when one of the sources supplies it.
+func NewValidator(node Node, sources ...Source) Validator {
+	return Validator{node: &node, sources: sources}
 }
 
-func NewValidator(node Node) Validator {
-	return Validator{node: &node}
+// Explicit returns the validator where the flags are not supplied by the sources, so they must be given on the command line.
+func (query Validator) Explicit(flags ...string) Validator {
+	sources := make([]Source, 0, len(query.sources))
+	for _, source := range query.sources {
+		sources = append(sources, Source{
+			Name: source.Name,
+			Lookup: func(flag string) (string, bool) {
+				if slices.Contains(flags, flag) {
+					return "", false
+				}
+				return source.Lookup(flag)
+			},
+		})
+	}
+	return Validator{node: query.node, sources: sources}
 }
 
+// Validate validates the flags, and then sets the flags not given on the command line to the values of the sources.
+// The flags of an alternative not taken on the command line are left unset.
 func (query Validator) Validate(cmd *cobra.Command) error {
-	_, err := query.node.validate(cmd, true, 0)
+	_, err := query.node.validate(cmd, query.sources, true, 0)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	excluded := query.node.excluded(cmd)
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || excluded[flag.Name] {
+			return
+		}
+		value, source, ok := lookup(query.sources, flag.Name)
+		if !ok {
+			return
+		}
+		log.Debug(fmt.Sprintf("Use --%s %s from %s", flag.Name, value, source.Name))
+		e := cmd.Flags().Set(flag.Name, value)
+		if e != nil {
+			err = fmt.Errorf("invalid --%s of %s: %w", flag.Name, source.Name, e)
+		}
+	})
+	return err
+}
+
+// lookup returns the value of the flag supplied by the first source supplying it.
+func lookup(sources []Source, flag string) (string, Source, bool) {
+	for _, source := range sources {
+		if value, ok := source.Lookup(flag); ok {
+			return value, source, true
+		}
+	}
+	return "", Source{Name: "", Lookup: nil}, false
 }
@@ -223,3 +223,163 @@ func TestQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatorSources(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{} //nolint:exhaustruct
+		cmd.Flags().String("field", "", "")
+		cmd.Flags().String("project-id", "", "")
+		cmd.Flags().Int("project", 0, "")
+		cmd.Flags().String("owner", "", "")
+		return cmd
+	}
+	env := flags.Source{
+		Name: "env",
+		Lookup: func(flag string) (string, bool) {
+			value, ok := map[string]string{"owner": "acme", "project": "1"}[flag]
+			return value, ok
+		},
+	}
+	config := flags.Source{
+		Name: "config",
+		Lookup: func(flag string) (string, bool) {
+			value, ok := map[string]string{"owner": "octocat", "field": "Sprint"}[flag]
+			return value, ok
+		},
+	}
+	validator := flags.NewValidator(
+		flags.And(
+			flags.Flag("field"),
+			flags.Or(
+				flags.Flag("project-id"),
+				flags.And(
+					flags.Flag("project"), flags.Flag("owner"),
+				),
+			),
+		),
+		env, config,
+	)
+
+	tests := []struct {
+		name   string
+		args   []string
+		want   map[string]string
+		errMsg string
+	}{
+		{
+			name: "supplied by the sources in order",
+			args: []string{},
+			want: map[string]string{"field": "Sprint", "project-id": "", "project": "1", "owner": "acme"},
+		},
+		{
+			name: "given on the command line",
+			args: []string{"--owner", "tasshi-me", "--field", "Iteration"},
+			want: map[string]string{"field": "Iteration", "project-id": "", "project": "1", "owner": "tasshi-me"},
+		},
+		{
+			name: "alternative given on the command line",
+			args: []string{"--project-id", "PVT_1"},
+			want: map[string]string{"field": "Sprint", "project-id": "PVT_1", "project": "0", "owner": ""},
+		},
+		{
+			name:   "alternatives given on the command line",
+			args:   []string{"--project-id", "PVT_1", "--project", "2"},
+			errMsg: "when you set [--project-id], you cannot set [--project --owner]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := newCmd()
+			err := cmd.ParseFlags(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = validator.Validate(cmd)
+			if len(tt.errMsg) > 0 {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("Want %s, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for flag, want := range tt.want {
+				if got := cmd.Flag(flag).Value.String(); got != want {
+					t.Errorf("wrong --%s want: %q, got %q", flag, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatorPartialSource(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{} //nolint:exhaustruct
+	cmd.Flags().String("project-id", "", "")
+	cmd.Flags().Int("project", 0, "")
+	cmd.Flags().String("owner", "", "")
+	repository := flags.Source{
+		Name: "repository",
+		Lookup: func(flag string) (string, bool) {
+			return "acme", flag == "owner"
+		},
+	}
+	validator := flags.NewValidator(
+		flags.Or(
+			flags.Flag("project-id"),
+			flags.And(flags.Flag("project"), flags.Flag("owner")),
+		),
+		repository,
+	)
+
+	err := cmd.ParseFlags([]string{"--project-id", "PVT_1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = validator.Validate(cmd)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if owner := cmd.Flag("owner").Value.String(); owner != "" {
+		t.Errorf("Want no owner, got %s", owner)
+	}
+}
+
+func TestValidatorExplicit(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{} //nolint:exhaustruct
+	cmd.Flags().String("field", "", "")
+	cmd.Flags().String("owner", "", "")
+	env := flags.Source{
+		Name: "env",
+		Lookup: func(flag string) (string, bool) {
+			value, ok := map[string]string{"owner": "acme", "field": "Sprint"}[flag]
+			return value, ok
+		},
+	}
+	validator := flags.NewValidator(flags.And(flags.Flag("field"), flags.Flag("owner")), env).Explicit("field")
+
+	err := validator.Validate(cmd)
+	if err == nil || err.Error() != "you must set all of (--field & --owner)" {
+		t.Errorf("Want an error of --field, got %v", err)
+	}
+
+	err = cmd.ParseFlags([]string{"--field", "Cycle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = validator.Validate(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner := cmd.Flag("owner").Value.String(); owner != "acme" {
+		t.Errorf("Want owner acme, got %s", owner)
+	}
+}
@@ -3,6 +3,8 @@ package flags
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -57,41 +59,90 @@ func (node Node) flagList() []string {
 	return nil
 }
 
-func (node Node) validate(cmd *cobra.Command, required bool, depth int) (bool, error) {
+// explicit reports whether any flag of the node is given on the command line.
+func (node Node) explicit(cmd *cobra.Command) bool {
 	switch {
 	case len(node.Flag) > 0:
-		return node.validateAsFlag(cmd, depth)
+		return cmd.Flag(node.Flag).Changed
 	case node.Or != nil:
-		return node.validateAsOr(cmd, required, depth)
+		return slices.ContainsFunc(node.Or, func(n Node) bool { return n.explicit(cmd) })
 	case node.And != nil:
-		return node.validateAsAnd(cmd, required, depth)
+		return slices.ContainsFunc(node.And, func(n Node) bool { return n.explicit(cmd) })
+	}
+	return false
+}
+
+// excluded returns the flags of the alternatives not taken, when an alternative is given on the command line.
+func (node Node) excluded(cmd *cobra.Command) map[string]bool {
+	excluded := map[string]bool{}
+	var children []Node
+	switch {
+	case node.Or != nil:
+		children = node.Or
+		if slices.ContainsFunc(node.Or, func(n Node) bool { return n.explicit(cmd) }) {
+			for _, n := range node.Or {
+				if !n.explicit(cmd) {
+					for _, flag := range n.flagList() {
+						excluded[strings.TrimPrefix(flag, "--")] = true
+					}
+				}
+			}
+		}
+	case node.And != nil:
+		children = node.And
+	}
+	for _, n := range children {
+		maps.Copy(excluded, n.excluded(cmd))
+	}
+	return excluded
+}
+
+func (node Node) validate(cmd *cobra.Command, sources []Source, required bool, depth int) (bool, error) {
+	switch {
+	case len(node.Flag) > 0:
+		return node.validateAsFlag(cmd, sources, depth)
+	case node.Or != nil:
+		return node.validateAsOr(cmd, sources, required, depth)
+	case node.And != nil:
+		return node.validateAsAnd(cmd, sources, required, depth)
 	}
 	return false, errors.New("invalid state") //nolint:goerr113
 }
 
-func (node Node) validateAsFlag(cmd *cobra.Command, depth int) (bool, error) {
+func (node Node) validateAsFlag(cmd *cobra.Command, sources []Source, depth int) (bool, error) {
 	filler := strings.Repeat("  ", depth)
 	log.Trace(filler + "Validate Flag Node: " + node.printRelation())
 	log.Trace(filler + fmt.Sprintf("Flag Node %s changed: %v", node.Flag, cmd.Flag(node.Flag).Changed))
-	return cmd.Flag(node.Flag).Changed, nil
+	if cmd.Flag(node.Flag).Changed {
+		return true, nil
+	}
+	_, _, supplied := lookup(sources, node.Flag)
+	log.Trace(filler + fmt.Sprintf("Flag Node %s supplied: %v", node.Flag, supplied))
+	return supplied, nil
 }
 
-func (node Node) validateAsOr(cmd *cobra.Command, required bool, depth int) (bool, error) {
+func (node Node) validateAsOr(cmd *cobra.Command, sources []Source, required bool, depth int) (bool, error) {
 	filler := strings.Repeat("  ", depth)
 
 	log.Trace(filler + "Validate OR Node: " + node.printRelation())
+	explicit := node.explicit(cmd)
 	changed := false
 	var changedNode Node
 	var invalidNodes []Node
 	var err error
 	for _, childNode := range node.Or {
-		nodeChanged, e := childNode.validate(cmd, false, depth+1)
+		if explicit && !childNode.explicit(cmd) {
+			// The alternatives supplied only by the sources give way to the one given on the command line.
+			continue
+		}
+		nodeChanged, e := childNode.validate(cmd, sources, false, depth+1)
 		err = e
 		if nodeChanged {
-			if changed {
-				invalidNodes = append(invalidNodes, childNode)
-			} else {
+			switch {
+			case !changed:
 				changedNode = childNode
+			case explicit:
+				invalidNodes = append(invalidNodes, childNode)
 			}
 		}
 		changed = changed || nodeChanged
@@ -123,7 +174,7 @@ func (node Node) validateAsOr(cmd *cobra.Command, required bool, depth int) (boo
 }
 
 //nolint:cyclop
-func (node Node) validateAsAnd(cmd *cobra.Command, required bool, depth int) (bool, error) {
+func (node Node) validateAsAnd(cmd *cobra.Command, sources []Source, required bool, depth int) (bool, error) {
 	filler := strings.Repeat("  ", depth)
 
 	log.Trace(filler + "Validate AND Node: " + node.printRelation())
@@ -132,7 +183,7 @@ func (node Node) validateAsAnd(cmd *cobra.Command, required bool, depth int) (bo
 	var unchangedNodes []Node
 	var err error
 	for _, childNode := range node.And {
-		nodeChanged, e := childNode.validate(cmd, required, depth+1)
+		nodeChanged, e := childNode.validate(cmd, sources, required, depth+1)
 		err = e
 		if nodeChanged {
 			changedNodes = append(changedNodes, childNode)
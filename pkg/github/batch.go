@@ -0,0 +1,100 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// MaxBatchSize is the maximum number of mutations sent in a request.
+const MaxBatchSize = 100
+
+// IterationFieldUpdate is an update of the iteration field value of a project item.
+// An empty IterationID clears the value.
+type IterationFieldUpdate struct {
+	ItemID      string
+	IterationID string
+}
+
+// UpdateIterationFields updates the iteration field values of the project items,
+// sending up to batchSize aliased mutations in a request.
+// It returns the error of each update in the order of the updates, nil for a successful one.
+func (c *Client) UpdateIterationFields(projectID string, fieldID string, updates []IterationFieldUpdate, batchSize int) ([]error, error) {
+	if batchSize < 1 || batchSize > MaxBatchSize {
+		return nil, fmt.Errorf("batch size must be between 1 and %d: %d", MaxBatchSize, batchSize)
+	}
+
+	errs := make([]error, len(updates))
+	for start := 0; start < len(updates); start += batchSize {
+		end := min(start+batchSize, len(updates))
+		copy(errs[start:end], c.updateIterationFieldsBatch(projectID, fieldID, updates[start:end]))
+	}
+	return errs, nil
+}
+
+// batchAlias returns the alias of the i-th mutation of a batch.
+func batchAlias(i int) string {
+	return "m" + strconv.Itoa(i)
+}
+
+func (c *Client) updateIterationFieldsBatch(projectID string, fieldID string, updates []IterationFieldUpdate) []error {
+	var definitions, selections []string
+	variables := map[string]interface{}{}
+	for i, update := range updates {
+		alias := batchAlias(i)
+		input := map[string]interface{}{
+			"fieldId":   fieldID,
+			"itemId":    update.ItemID,
+			"projectId": projectID,
+		}
+		if len(update.IterationID) > 0 {
+			// https://docs.github.com/en/graphql/reference/mutations#updateprojectv2itemfieldvalue
+			input["value"] = map[string]interface{}{"iterationId": update.IterationID}
+			definitions = append(definitions, fmt.Sprintf("$%s:UpdateProjectV2ItemFieldValueInput!", alias))
+			selections = append(selections, fmt.Sprintf("%s:updateProjectV2ItemFieldValue(input: $%s){projectV2Item{id}}", alias, alias))
+		} else {
+			// https://docs.github.com/en/graphql/reference/mutations#clearprojectv2itemfieldvalue
+			definitions = append(definitions, fmt.Sprintf("$%s:ClearProjectV2ItemFieldValueInput!", alias))
+			selections = append(selections, fmt.Sprintf("%s:clearProjectV2ItemFieldValue(input: $%s){projectV2Item{id}}", alias, alias))
+		}
+		variables[alias] = input
+	}
+	query := fmt.Sprintf("mutation UpdateIterationFields(%s){%s}", strings.Join(definitions, ","), strings.Join(selections, ""))
+
+	var response map[string]*struct {
+		ProjectV2Item struct {
+			ID string `json:"id"`
+		} `json:"projectV2Item"`
+	}
+	err := c.gql.Do(query, variables, &response)
+
+	errs := make([]error, len(updates))
+	var gqlErr *api.GraphQLError
+	if err != nil && !errors.As(err, &gqlErr) {
+		for i := range errs {
+			errs[i] = fmt.Errorf("failed to update the iteration field: %w", err)
+		}
+		return errs
+	}
+
+	for i := range updates {
+		alias := batchAlias(i)
+		updated := response[alias] != nil && len(response[alias].ProjectV2Item.ID) > 0
+		if gqlErr != nil {
+			for _, e := range gqlErr.Errors {
+				// An error without a path is charged to the mutations without results, as it cannot be told whose it is.
+				if (len(e.Path) > 0 && e.Path[0] == alias) || (len(e.Path) == 0 && !updated) {
+					errs[i] = fmt.Errorf("failed to update the iteration field: %w", &api.GraphQLError{Errors: []api.GraphQLErrorItem{e}})
+					break
+				}
+			}
+		}
+		if errs[i] == nil && !updated {
+			errs[i] = errors.New("failed to update the iteration field: no item in the response")
+		}
+	}
+	return errs
+}
@@ -0,0 +1,82 @@
+package github_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+func TestUpdateIterationFields(t *testing.T) {
+	t.Parallel()
+
+	client, server := newFakeClient(t)
+	updates := []github.IterationFieldUpdate{
+		{ItemID: "PVTI_1", IterationID: "sprint_4"},
+		{ItemID: "PVTI_2", IterationID: "no_such_iteration"},
+		{ItemID: "PVTI_5", IterationID: ""},
+		{ItemID: "PVTI_404", IterationID: "sprint_4"},
+		{ItemID: "PVTI_6", IterationID: "sprint_5"},
+	}
+	errs, err := client.UpdateIterationFields("PVT_roadmap", "PVTIF_sprint", updates, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFailed := []bool{false, true, false, true, false}
+	for i, e := range errs {
+		if (e != nil) != wantFailed[i] {
+			t.Errorf("wrong result of %s: %v", updates[i].ItemID, e)
+		}
+	}
+
+	wantValues := map[string]any{"PVTI_1": "sprint_4", "PVTI_2": "sprint_2", "PVTI_5": nil, "PVTI_6": "sprint_5"}
+	for itemID, want := range wantValues {
+		if got := server.FieldValue(itemID, "Sprint"); got != want {
+			t.Errorf("wrong Sprint of %s want: %v, got %v", itemID, want, got)
+		}
+	}
+
+	wantOperations := []string{"UpdateIterationFields", "UpdateIterationFields", "UpdateIterationFields"}
+	if got := server.Operations(); !slices.Equal(got, wantOperations) {
+		t.Errorf("wrong operations want: %v, got %v", wantOperations, got)
+	}
+}
+
+func TestUpdateIterationFieldsBatchSize(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient(t)
+	for _, batchSize := range []int{0, 101} {
+		_, err := client.UpdateIterationFields("PVT_roadmap", "PVTIF_sprint", nil, batchSize)
+		if err == nil {
+			t.Errorf("no error for batch size %d", batchSize)
+		}
+	}
+}
+
+func TestUpdateIterationFieldsErrorWithoutPath(t *testing.T) {
+	t.Parallel()
+
+	client, server := newFakeClient(t)
+	server.FailItem("PVTI_2")
+	server.OmitErrorPaths()
+	updates := []github.IterationFieldUpdate{
+		{ItemID: "PVTI_1", IterationID: "sprint_4"},
+		{ItemID: "PVTI_2", IterationID: "sprint_4"},
+	}
+	errs, err := client.UpdateIterationFields("PVT_roadmap", "PVTIF_sprint", updates, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs[0] != nil {
+		t.Errorf("wrong result of PVTI_1: %v", errs[0])
+	}
+	if errs[1] == nil || !strings.Contains(errs[1].Error(), "PVTI_2") {
+		t.Errorf("wrong result of PVTI_2: %v", errs[1])
+	}
+	if got := server.FieldValue("PVTI_1", "Sprint"); got != "sprint_4" {
+		t.Errorf("wrong Sprint of PVTI_1 want: sprint_4, got %v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// GraphQLClient sends GraphQL queries and mutations built from Go structs.
+// *api.GraphQLClient satisfies this interface.
+type GraphQLClient interface {
+	Query(name string, query interface{}, variables map[string]interface{}) error
+	Mutate(name string, mutation interface{}, variables map[string]interface{}) error
+	// Do sends a query written in a string, for the ones which cannot be built from Go structs.
+	Do(query string, variables map[string]interface{}, response interface{}) error
+}
+
+// Client retrieves and updates GitHub Projects through a GraphQL client.
+type Client struct {
+	gql  GraphQLClient
+	host string
+}
+
+// NewClient creates a client that sends its requests through gql, to a host it does not know.
+func NewClient(gql GraphQLClient) *Client {
+	return &Client{gql: gql, host: ""}
+}
+
+// NewDefaultClient creates a client with the host and the token resolved from the gh environment.
+func NewDefaultClient() (*Client, error) {
+	return NewClientWithOptions(api.ClientOptions{}) //nolint:exhaustruct
+}
+
+// NewClientWithOptions creates a client with the host, token and transport given by opts.
+// Options left empty are resolved from the gh environment.
+// Requests hitting the rate limits of GitHub are retried after the wait GitHub asks for.
+func NewClientWithOptions(opts api.ClientOptions) (*Client, error) {
+	if len(opts.Host) == 0 {
+		opts.Host, _ = auth.DefaultHost()
+	}
+	opts.Transport = newRateLimitTransport(opts.Transport)
+	gql, err := api.NewGraphQLClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
+	}
+	return &Client{gql: gql, host: auth.NormalizeHostname(opts.Host)}, nil
+}
+
+// Host returns the normalized name of the GitHub host the client sends its requests to, or empty if it is not known.
+func (c *Client) Host() string {
+	return c.host
+}
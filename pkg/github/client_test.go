@@ -0,0 +1,152 @@
+package github_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+// newClient creates a client with the host and the token resolved from the gh environment.
+func newClient(t *testing.T) *github.Client {
+	t.Helper()
+
+	client, err := github.NewDefaultClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// newFakeClient starts a fake server serving the default fixture and creates a client for it.
+func newFakeClient(t *testing.T) (*github.Client, *githubtest.Server) {
+	t.Helper()
+
+	server := githubtest.NewServer(githubtest.DefaultFixture())
+	t.Cleanup(server.Close)
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+var errFakeQuery = errors.New("fake query error")
+
+type fakeGraphQLClient struct {
+	queries   []string
+	mutations []string
+}
+
+func (f *fakeGraphQLClient) Query(name string, _ interface{}, _ map[string]interface{}) error {
+	f.queries = append(f.queries, name)
+	return errFakeQuery
+}
+
+func (f *fakeGraphQLClient) Mutate(name string, _ interface{}, _ map[string]interface{}) error {
+	f.mutations = append(f.mutations, name)
+	return errFakeQuery
+}
+
+func (f *fakeGraphQLClient) Do(_ string, _ map[string]interface{}, _ interface{}) error {
+	return errFakeQuery
+}
+
+func TestClientWrapsGraphQLErrors(t *testing.T) {
+	t.Parallel()
+
+	gql := &fakeGraphQLClient{queries: nil, mutations: nil}
+	client := github.NewClient(gql)
+
+	_, err := client.FetchProjectItem("PVTI_1")
+	if !errors.Is(err, errFakeQuery) {
+		t.Errorf("want %v, got %v", errFakeQuery, err)
+	}
+	_, err = client.UpdateIterationField("PVT_1", "PVTIF_1", "PVTI_1", "iter_1")
+	if !errors.Is(err, errFakeQuery) {
+		t.Errorf("want %v, got %v", errFakeQuery, err)
+	}
+
+	if len(gql.queries) != 1 || gql.queries[0] != "ProjectItem" {
+		t.Errorf("wrong queries: %v", gql.queries)
+	}
+	if len(gql.mutations) != 1 || gql.mutations[0] != "updateProjectV2ItemFieldValue" {
+		t.Errorf("wrong mutations: %v", gql.mutations)
+	}
+}
+
+func TestClientValidatesProjectNumber(t *testing.T) {
+	t.Parallel()
+
+	gql := &fakeGraphQLClient{queries: nil, mutations: nil}
+	client := github.NewClient(gql)
+
+	_, err := client.FetchProjectByNumber(1<<40, "O_1")
+	if err == nil {
+		t.Error("want error, got nil")
+	}
+	if len(gql.queries) > 0 {
+		t.Errorf("want no queries, got %v", gql.queries)
+	}
+}
+
+// redirectTransport sends every request to the server regardless of the requested host.
+type redirectTransport struct {
+	server *httptest.Server
+}
+
+func (r redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(r.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	return r.server.Client().Transport.RoundTrip(req) //nolint:wrapcheck
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "token secret" {
+			t.Errorf("wrong authorization header: %s", got)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if !strings.Contains(string(body), "organization(login: $login)") {
+			t.Errorf("unexpected query: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":{"organization":{"id":"O_1","login":"my-org","name":"My Org"},"user":null}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := github.NewClientWithOptions(api.ClientOptions{ //nolint:exhaustruct
+		Host:         "github.example.com",
+		AuthToken:    "secret",
+		Transport:    redirectTransport{server: server},
+		LogIgnoreEnv: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := client.FetchOwnerByLogin("my-org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.ID != "O_1" || owner.Login != "my-org" || owner.Type != github.OwnerTypeOrganization {
+		t.Errorf("wrong owner: %+v", owner)
+	}
+}
@@ -0,0 +1,197 @@
+package githubtest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// object is a node of the object graph served by the fake server.
+type object struct {
+	Typename string
+	// Fields holds scalars, *object, []*object and connection values keyed by the GraphQL field name.
+	Fields map[string]any
+}
+
+func newObject(typename string, fields map[string]any) *object {
+	return &object{Typename: typename, Fields: fields}
+}
+
+// connection is a list field paginated with the first and after arguments.
+type connection []*object
+
+// interfaces lists the object types implementing the abstract types used in type conditions.
+//
+//nolint:gochecknoglobals
+var interfaces = map[string][]string{
+	"ProjectV2Owner":       {"User", "Organization"},
+	"ProjectV2FieldCommon": {"ProjectV2Field", "ProjectV2IterationField", "ProjectV2SingleSelectField"},
+	"ProjectV2FieldConfiguration": {
+		"ProjectV2Field", "ProjectV2IterationField", "ProjectV2SingleSelectField",
+	},
+	"ProjectV2ItemFieldValueCommon": {
+		"ProjectV2ItemFieldDateValue", "ProjectV2ItemFieldIterationValue", "ProjectV2ItemFieldNumberValue",
+		"ProjectV2ItemFieldSingleSelectValue", "ProjectV2ItemFieldTextValue",
+	},
+}
+
+// satisfies reports whether an object of the type satisfies the type condition of an inline fragment.
+func satisfies(typename string, condition string) bool {
+	return typename == condition || condition == "Node" || slices.Contains(interfaces[condition], typename)
+}
+
+// resolver resolves a field which takes arguments or is computed from the state of the server.
+type resolver func(s *Server, obj *object, args map[string]any) (any, error)
+
+// graphQLError is an error entry of a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+// fieldError is returned by resolvers to report an error of the GraphQL error type.
+type fieldError struct {
+	Type    string
+	Message string
+}
+
+func (e *fieldError) Error() string {
+	return e.Message
+}
+
+func notFound(format string, args ...any) error {
+	return &fieldError{Type: "NOT_FOUND", Message: fmt.Sprintf(format, args...)}
+}
+
+// executor executes an operation against the object graph and collects the errors.
+type executor struct {
+	server    *Server
+	variables map[string]any
+	errors    []graphQLError
+}
+
+func (e *executor) selectionSet(obj *object, selections []selection, path []any) map[string]any {
+	result := map[string]any{}
+	e.mergeSelectionSet(result, obj, selections, path)
+	return result
+}
+
+func (e *executor) mergeSelectionSet(result map[string]any, obj *object, selections []selection, path []any) {
+	for _, sel := range selections {
+		if len(sel.On) > 0 {
+			if satisfies(obj.Typename, sel.On) {
+				e.mergeSelectionSet(result, obj, sel.Selections, path)
+			}
+			continue
+		}
+		key := sel.responseKey()
+		result[key] = e.field(obj, sel, append(slices.Clone(path), key))
+	}
+}
+
+func (e *executor) field(obj *object, sel selection, path []any) any {
+	if sel.Name == "__typename" {
+		return obj.Typename
+	}
+
+	args := make(map[string]any, len(sel.Args))
+	for name, v := range sel.Args {
+		args[name] = v.resolve(e.variables)
+	}
+
+	var v any
+	if r, ok := resolvers[obj.Typename+"."+sel.Name]; ok {
+		resolved, err := r(e.server, obj, args)
+		if err != nil {
+			e.addError(err, path)
+			return nil
+		}
+		v = resolved
+	} else {
+		v = obj.Fields[sel.Name]
+	}
+
+	completed, err := e.complete(v, sel, args, path)
+	if err != nil {
+		e.addError(err, path)
+		return nil
+	}
+	return completed
+}
+
+func (e *executor) complete(v any, sel selection, args map[string]any, path []any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case *object:
+		if v == nil {
+			return nil, nil
+		}
+		if sel.Selections == nil {
+			return nil, fmt.Errorf("field '%s' of type '%s' must have a selection of subfields", sel.Name, v.Typename)
+		}
+		return e.selectionSet(v, sel.Selections, path), nil
+	case []*object:
+		list := make([]any, 0, len(v))
+		for i, o := range v {
+			list = append(list, e.selectionSet(o, sel.Selections, append(slices.Clone(path), i)))
+		}
+		return list, nil
+	case connection:
+		page, err := paginate(v, args)
+		if err != nil {
+			return nil, err
+		}
+		return e.selectionSet(page, sel.Selections, path), nil
+	default:
+		if sel.Selections != nil {
+			return nil, fmt.Errorf("selections can't be made on scalars (field '%s')", sel.Name)
+		}
+		return v, nil
+	}
+}
+
+func (e *executor) addError(err error, path []any) {
+	if e.server.omitErrorPaths {
+		path = nil
+	}
+	gqlErr := graphQLError{Type: "", Message: err.Error(), Path: path}
+	if fe, ok := err.(*fieldError); ok { //nolint:errorlint
+		gqlErr.Type = fe.Type
+	}
+	e.errors = append(e.errors, gqlErr)
+}
+
+// paginate returns the page of the connection selected by the first and after arguments.
+func paginate(nodes connection, args map[string]any) (*object, error) {
+	start := 0
+	if after, ok := args["after"].(string); ok {
+		n, err := strconv.Atoi(after)
+		if err != nil || n < 0 || n > len(nodes) {
+			return nil, fmt.Errorf("invalid cursor: %q", after)
+		}
+		start = n
+	}
+
+	first, ok := args["first"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("you must provide a `first` value to properly paginate the connection")
+	}
+	if first < 0 || first > maxPageSize {
+		return nil, fmt.Errorf("requesting %v records on the connection exceeds the `first` limit of %d records", first, maxPageSize)
+	}
+	end := min(start+int(first), len(nodes))
+
+	return newObject("Connection", map[string]any{
+		"nodes":      []*object(nodes[start:end]),
+		"totalCount": len(nodes),
+		"pageInfo": newObject("PageInfo", map[string]any{
+			"hasNextPage": end < len(nodes),
+			"endCursor":   strconv.Itoa(end),
+		}),
+	}), nil
+}
+
+// maxPageSize is the maximum number of nodes GitHub returns for a page of a connection.
+const maxPageSize = 100
@@ -0,0 +1,315 @@
+package githubtest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Fixture describes the users, organizations and projects served by the fake server.
+type Fixture struct {
+	// Today is the date (YYYY-MM-DD) the server uses to tell the completed iterations. It defaults to the current date.
+	Today string `json:"today"`
+	// Viewer is the login of the authenticated user.
+	Viewer        string         `json:"viewer"`
+	Users         []FixtureOwner `json:"users"`
+	Organizations []FixtureOwner `json:"organizations"`
+}
+
+type FixtureOwner struct {
+	ID       string           `json:"id"`
+	Login    string           `json:"login"`
+	Name     string           `json:"name"`
+	Projects []FixtureProject `json:"projects"`
+}
+
+type FixtureProject struct {
+	ID     string         `json:"id"`
+	Number int            `json:"number"`
+	Title  string         `json:"title"`
+	Fields []FixtureField `json:"fields"`
+	Items  []FixtureItem  `json:"items"`
+}
+
+type FixtureField struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+	// Options is the options of a SINGLE_SELECT field.
+	Options []FixtureOption `json:"options,omitempty"`
+	// Configuration is the configuration of an ITERATION field.
+	Configuration *FixtureIterationConfiguration `json:"configuration,omitempty"`
+}
+
+type FixtureOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type FixtureIterationConfiguration struct {
+	StartDate           string             `json:"startDate"`
+	Duration            int                `json:"duration"`
+	Iterations          []FixtureIteration `json:"iterations"`
+	CompletedIterations []FixtureIteration `json:"completedIterations"`
+}
+
+type FixtureIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+type FixtureItem struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"` // DRAFT_ISSUE, ISSUE, PULL_REQUEST
+	IsArchived bool   `json:"isArchived"`
+	// Content holds the fields of the issue, pull request or draft issue.
+	// "repository" is given as the name with owner, and "author" and "creator" as the login.
+	Content map[string]any `json:"content"`
+	// FieldValues maps a field name to its value:
+	// an iteration ID for ITERATION, an option name for SINGLE_SELECT,
+	// a number for NUMBER, a string for TEXT, DATE, MILESTONE (title) and REPOSITORY (name with owner),
+	// and a list of label names, user logins, reviewers ("org/slug" for teams) or pull request numbers
+	// for LABELS, ASSIGNEES, REVIEWERS and LINKED_PULL_REQUESTS.
+	FieldValues map[string]any `json:"fieldValues"`
+}
+
+//go:embed fixture.json
+var defaultFixture []byte
+
+// DefaultFixture returns a fresh copy of the fixture embedded in this package.
+func DefaultFixture() *Fixture {
+	var fixture Fixture
+	err := json.Unmarshal(defaultFixture, &fixture)
+	if err != nil {
+		panic(fmt.Errorf("invalid default fixture: %w", err))
+	}
+	return &fixture
+}
+
+// LoadFixture reads a fixture from a JSON file.
+func LoadFixture(path string) (*Fixture, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var fixture Fixture
+	err = json.Unmarshal(bytes, &fixture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+	return &fixture, nil
+}
+
+// contentTypenames maps the type of a project item to the GraphQL type of its content.
+//
+//nolint:gochecknoglobals
+var contentTypenames = map[string]string{
+	"DRAFT_ISSUE":  "DraftIssue",
+	"ISSUE":        "Issue",
+	"PULL_REQUEST": "PullRequest",
+}
+
+// load builds the object graph of the fixture.
+func (s *Server) load(fixture *Fixture) error {
+	s.today = time.Now().UTC().Truncate(24 * time.Hour) //nolint:mnd
+	if len(fixture.Today) > 0 {
+		today, err := time.Parse(time.DateOnly, fixture.Today)
+		if err != nil {
+			return fmt.Errorf("invalid today: %w", err)
+		}
+		s.today = today
+	}
+
+	for _, owner := range fixture.Users {
+		obj, err := s.loadOwner("User", owner)
+		if err != nil {
+			return err
+		}
+		s.users[owner.Login] = obj
+	}
+	for _, owner := range fixture.Organizations {
+		obj, err := s.loadOwner("Organization", owner)
+		if err != nil {
+			return err
+		}
+		s.organizations[owner.Login] = obj
+	}
+
+	if len(fixture.Viewer) > 0 {
+		viewer, ok := s.users[fixture.Viewer]
+		if !ok {
+			return fmt.Errorf("viewer is not a user of the fixture: %s", fixture.Viewer)
+		}
+		s.viewer = viewer
+	}
+	return nil
+}
+
+func (s *Server) loadOwner(typename string, owner FixtureOwner) (*object, error) {
+	projects := make([]*object, 0, len(owner.Projects))
+	obj := newObject(typename, map[string]any{
+		"id":    owner.ID,
+		"login": owner.Login,
+		"name":  owner.Name,
+	})
+	s.addNode(owner.ID, obj)
+
+	for _, project := range owner.Projects {
+		p, err := s.loadProject(project, obj)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	obj.Fields["projectsV2"] = connection(projects)
+	return obj, nil
+}
+
+func (s *Server) loadProject(project FixtureProject, owner *object) (*object, error) {
+	obj := newObject("ProjectV2", map[string]any{
+		"id":     project.ID,
+		"number": project.Number,
+		"title":  project.Title,
+		"owner":  owner,
+	})
+	s.addNode(project.ID, obj)
+
+	fields := make(connection, 0, len(project.Fields))
+	for _, field := range project.Fields {
+		fields = append(fields, s.loadField(field))
+	}
+	obj.Fields["fields"] = fields
+
+	items := make(connection, 0, len(project.Items))
+	for _, item := range project.Items {
+		i, err := s.loadItem(item, obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	obj.Fields["items"] = items
+	return obj, nil
+}
+
+func (s *Server) loadField(field FixtureField) *object {
+	obj := newObject("ProjectV2Field", map[string]any{
+		"id":       field.ID,
+		"name":     field.Name,
+		"dataType": field.DataType,
+	})
+
+	switch field.DataType {
+	case "ITERATION":
+		obj.Typename = "ProjectV2IterationField"
+		config := field.Configuration
+		if config == nil {
+			config = &FixtureIterationConfiguration{StartDate: "", Duration: 0, Iterations: nil, CompletedIterations: nil}
+		}
+		obj.Fields["configuration"] = newIterationConfiguration(config)
+	case "SINGLE_SELECT":
+		obj.Typename = "ProjectV2SingleSelectField"
+		options := make([]*object, 0, len(field.Options))
+		for _, option := range field.Options {
+			options = append(options, newObject("ProjectV2SingleSelectFieldOption", map[string]any{
+				"id":   option.ID,
+				"name": option.Name,
+			}))
+		}
+		obj.Fields["options"] = options
+	}
+
+	s.addNode(field.ID, obj)
+	return obj
+}
+
+func newIterationConfiguration(config *FixtureIterationConfiguration) *object {
+	return newObject("ProjectV2IterationFieldConfiguration", map[string]any{
+		"startDate":           config.StartDate,
+		"duration":            config.Duration,
+		"iterations":          newIterations(config.Iterations),
+		"completedIterations": newIterations(config.CompletedIterations),
+	})
+}
+
+func newIterations(iterations []FixtureIteration) []*object {
+	objs := make([]*object, 0, len(iterations))
+	for _, iteration := range iterations {
+		objs = append(objs, newObject("ProjectV2IterationFieldIteration", map[string]any{
+			"id":        iteration.ID,
+			"title":     iteration.Title,
+			"titleHTML": iteration.Title,
+			"startDate": iteration.StartDate,
+			"duration":  iteration.Duration,
+		}))
+	}
+	return objs
+}
+
+func (s *Server) loadItem(item FixtureItem, project *object) (*object, error) {
+	obj := newObject("ProjectV2Item", map[string]any{
+		"id":          item.ID,
+		"type":        item.Type,
+		"isArchived":  item.IsArchived,
+		"project":     project,
+		"fieldValues": connection{},
+	})
+	s.addNode(item.ID, obj)
+
+	typename, ok := contentTypenames[item.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown item type of %s: %s", item.ID, item.Type)
+	}
+	content := newObject(typename, map[string]any{})
+	for name, v := range item.Content {
+		content.Fields[name] = v
+	}
+	if nameWithOwner, ok := item.Content["repository"].(string); ok {
+		content.Fields["repository"] = newRepository(nameWithOwner)
+	}
+	for _, name := range []string{"author", "creator"} {
+		if login, ok := item.Content[name].(string); ok {
+			content.Fields[name] = newObject("User", map[string]any{"login": login})
+		}
+	}
+	obj.Fields["content"] = content
+
+	for fieldName := range item.FieldValues {
+		if findField(project, "name", fieldName) == nil {
+			return nil, fmt.Errorf("unknown field of %s: %s", item.ID, fieldName)
+		}
+	}
+	// Field values are listed in the order of the fields of the project.
+	fields, _ := project.Fields["fields"].(connection)
+	for _, field := range fields {
+		fieldName, _ := field.Fields["name"].(string)
+		v, ok := item.FieldValues[fieldName]
+		if !ok {
+			continue
+		}
+		fieldValue, err := newFieldValue(field, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s on %s: %w", fieldName, item.ID, err)
+		}
+		setFieldValue(obj, fieldValue)
+	}
+	return obj, nil
+}
+
+func newRepository(nameWithOwner string) *object {
+	name := nameWithOwner
+	for i := len(nameWithOwner) - 1; i >= 0; i-- {
+		if nameWithOwner[i] == '/' {
+			name = nameWithOwner[i+1:]
+			break
+		}
+	}
+	return newObject("Repository", map[string]any{
+		"name":          name,
+		"nameWithOwner": nameWithOwner,
+	})
+}
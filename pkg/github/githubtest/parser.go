@@ -0,0 +1,369 @@
+package githubtest
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// operation is a parsed GraphQL query or mutation.
+type operation struct {
+	Type       string // query, mutation
+	Name       string
+	Selections []selection
+}
+
+// selection is a field or an inline fragment of a selection set.
+type selection struct {
+	Alias string
+	Name  string
+	Args  map[string]value
+	// On is the type condition of an inline fragment. It is empty for a field.
+	On         string
+	Selections []selection
+}
+
+// responseKey is the key of the field in the response object.
+func (s selection) responseKey() string {
+	if len(s.Alias) > 0 {
+		return s.Alias
+	}
+	return s.Name
+}
+
+// value is an argument value. It is resolved against the variables of the request.
+type value struct {
+	Variable string
+	Literal  any
+}
+
+func (v value) resolve(variables map[string]any) any {
+	if len(v.Variable) > 0 {
+		return variables[v.Variable]
+	}
+	return v.Literal
+}
+
+var errUnexpectedEOF = errors.New("unexpected end of query")
+
+type parser struct {
+	src string
+	pos int
+}
+
+// parseOperation parses a single operation document such as the ones built by shurcooL-graphql.
+func parseOperation(src string) (*operation, error) {
+	p := &parser{src: src, pos: 0}
+	op := &operation{Type: "query", Name: "", Selections: nil}
+
+	p.skipIgnored()
+	if p.peek() != '{' {
+		op.Type = p.name()
+		if op.Type != "query" && op.Type != "mutation" {
+			return nil, fmt.Errorf("unsupported operation type: %q", op.Type)
+		}
+		p.skipIgnored()
+		if isNameStart(p.peek()) {
+			op.Name = p.name()
+			p.skipIgnored()
+		}
+		if p.peek() == '(' {
+			err := p.skipVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = selections
+
+	p.skipIgnored()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at %d", p.src[p.pos:], p.pos)
+	}
+	return op, nil
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipIgnored skips white spaces, commas and comments, which are insignificant in GraphQL.
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) expect(s string) error {
+	p.skipIgnored()
+	if !strings.HasPrefix(p.src[p.pos:], s) {
+		if p.pos >= len(p.src) {
+			return errUnexpectedEOF
+		}
+		return fmt.Errorf("expected %q at %d, got %q", s, p.pos, p.src[p.pos:])
+	}
+	p.pos += len(s)
+	return nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+func (p *parser) name() string {
+	p.skipIgnored()
+	start := p.pos
+	if p.pos < len(p.src) && isNameStart(p.src[p.pos]) {
+		p.pos++
+		for p.pos < len(p.src) && isNameContinue(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) skipVariableDefinitions() error {
+	depth := 0
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+		p.pos++
+	}
+	return errUnexpectedEOF
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	err := p.expect("{")
+	if err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for {
+		p.skipIgnored()
+		if p.pos >= len(p.src) {
+			return nil, errUnexpectedEOF
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return selections, nil
+		}
+
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, *sel)
+	}
+}
+
+func (p *parser) selection() (*selection, error) {
+	sel := &selection{Alias: "", Name: "", Args: nil, On: "", Selections: nil}
+
+	if strings.HasPrefix(p.src[p.pos:], "...") {
+		p.pos += len("...")
+		if p.name() != "on" {
+			return nil, fmt.Errorf("only inline fragments are supported at %d", p.pos)
+		}
+		sel.On = p.name()
+		selections, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		sel.Selections = selections
+		return sel, nil
+	}
+
+	sel.Name = p.name()
+	if len(sel.Name) == 0 {
+		return nil, fmt.Errorf("expected a field name at %d, got %q", p.pos, p.src[p.pos:])
+	}
+	p.skipIgnored()
+	if p.peek() == ':' {
+		p.pos++
+		sel.Alias = sel.Name
+		sel.Name = p.name()
+		p.skipIgnored()
+	}
+
+	if p.peek() == '(' {
+		args, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		sel.Args = args
+		p.skipIgnored()
+	}
+
+	if p.peek() == '{' {
+		selections, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		sel.Selections = selections
+	}
+	return sel, nil
+}
+
+func (p *parser) arguments() (map[string]value, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]value{}
+	for {
+		p.skipIgnored()
+		if p.peek() == ')' {
+			p.pos++
+			return args, nil
+		}
+		name := p.name()
+		if len(name) == 0 {
+			return nil, fmt.Errorf("expected an argument name at %d", p.pos)
+		}
+		err := p.expect(":")
+		if err != nil {
+			return nil, err
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = *v
+	}
+}
+
+//nolint:cyclop
+func (p *parser) value() (*value, error) {
+	p.skipIgnored()
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, errUnexpectedEOF
+	case c == '$':
+		p.pos++
+		return &value{Variable: p.name(), Literal: nil}, nil
+	case c == '"':
+		return p.stringValue()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.numberValue()
+	case c == '[':
+		p.pos++
+		var list []any
+		for {
+			p.skipIgnored()
+			if p.peek() == ']' {
+				p.pos++
+				return &value{Variable: "", Literal: list}, nil
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v.Literal)
+		}
+	case c == '{':
+		p.pos++
+		obj := map[string]any{}
+		for {
+			p.skipIgnored()
+			if p.peek() == '}' {
+				p.pos++
+				return &value{Variable: "", Literal: obj}, nil
+			}
+			name := p.name()
+			err := p.expect(":")
+			if err != nil {
+				return nil, err
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v.Literal
+		}
+	default:
+		switch name := p.name(); name {
+		case "":
+			return nil, fmt.Errorf("expected a value at %d, got %q", p.pos, p.src[p.pos:])
+		case "true":
+			return &value{Variable: "", Literal: true}, nil
+		case "false":
+			return &value{Variable: "", Literal: false}, nil
+		case "null":
+			return &value{Variable: "", Literal: nil}, nil
+		default:
+			// Enum values are represented by their names.
+			return &value{Variable: "", Literal: name}, nil
+		}
+	}
+}
+
+func (p *parser) stringValue() (*value, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %w", start, err)
+			}
+			return &value{Variable: "", Literal: s}, nil
+		default:
+			p.pos++
+		}
+	}
+	return nil, errUnexpectedEOF
+}
+
+func (p *parser) numberValue() (*value, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) && strings.ContainsRune("0123456789.eE+-", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	literal := p.src[start:p.pos]
+	if i, err := strconv.Atoi(literal); err == nil {
+		return &value{Variable: "", Literal: float64(i)}, nil
+	}
+	f, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number at %d: %w", start, err)
+	}
+	return &value{Variable: "", Literal: f}, nil
+}
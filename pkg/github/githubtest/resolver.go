@@ -0,0 +1,489 @@
+package githubtest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// resolvers resolves the fields keyed by "Type.field".
+//
+//nolint:gochecknoglobals
+var resolvers = map[string]resolver{
+	"Query.node":                             resolveNode,
+	"Query.viewer":                           resolveViewer,
+	"Query.user":                             resolveUser,
+	"Query.organization":                     resolveOrganization,
+	"User.projectV2":                         resolveProjectV2,
+	"Organization.projectV2":                 resolveProjectV2,
+	"ProjectV2.field":                        resolveProjectV2Field,
+	"ProjectV2Item.fieldValueByName":         resolveFieldValueByName,
+	"Mutation.updateProjectV2ItemFieldValue": resolveUpdateProjectV2ItemFieldValue,
+	"Mutation.clearProjectV2ItemFieldValue":  resolveClearProjectV2ItemFieldValue,
+	"Mutation.updateProjectV2Field":          resolveUpdateProjectV2Field,
+	"Mutation.createProjectV2Field":          resolveCreateProjectV2Field,
+}
+
+var errInputRequired = errors.New("argument 'input' is required")
+
+func resolveFieldValueByName(_ *Server, item *object, args map[string]any) (any, error) {
+	values, _ := item.Fields["fieldValues"].(connection)
+	for _, v := range values {
+		if field, _ := v.Fields["field"].(*object); field.Fields["name"] == args["name"] {
+			return v, nil
+		}
+	}
+	return nil, nil //nolint:nilnil
+}
+
+func resolveNode(s *Server, _ *object, args map[string]any) (any, error) {
+	id, _ := args["id"].(string)
+	node, ok := s.nodes[id]
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	return node, nil
+}
+
+func resolveViewer(s *Server, _ *object, _ map[string]any) (any, error) {
+	if s.viewer == nil {
+		return nil, &fieldError{Type: "FORBIDDEN", Message: "This endpoint requires you to be authenticated."}
+	}
+	return s.viewer, nil
+}
+
+func resolveUser(s *Server, _ *object, args map[string]any) (any, error) {
+	login, _ := args["login"].(string)
+	user, ok := s.users[login]
+	if !ok {
+		return nil, notFound("Could not resolve to a User with the login of '%s'.", login)
+	}
+	return user, nil
+}
+
+func resolveOrganization(s *Server, _ *object, args map[string]any) (any, error) {
+	login, _ := args["login"].(string)
+	org, ok := s.organizations[login]
+	if !ok {
+		return nil, notFound("Could not resolve to an Organization with the login of '%s'.", login)
+	}
+	return org, nil
+}
+
+func resolveProjectV2(_ *Server, owner *object, args map[string]any) (any, error) {
+	number, _ := args["number"].(float64)
+	projects, _ := owner.Fields["projectsV2"].(connection)
+	for _, project := range projects {
+		if project.Fields["number"] == int(number) {
+			return project, nil
+		}
+	}
+	return nil, notFound("Could not resolve to a ProjectV2 with the number %d.", int(number))
+}
+
+func resolveProjectV2Field(_ *Server, project *object, args map[string]any) (any, error) {
+	name, _ := args["name"].(string)
+	return findField(project, "name", name), nil
+}
+
+// findField returns the field of the project whose key equals to the value, or nil if not found.
+func findField(project *object, key string, value any) *object {
+	fields, _ := project.Fields["fields"].(connection)
+	for _, field := range fields {
+		if field.Fields[key] == value {
+			return field
+		}
+	}
+	return nil
+}
+
+// mutationTarget returns the project item, the project and the field referred by a mutation input.
+func (s *Server) mutationTarget(args map[string]any) (map[string]any, *object, *object, error) {
+	input, ok := args["input"].(map[string]any)
+	if !ok {
+		return nil, nil, nil, errInputRequired
+	}
+
+	projectID, _ := input["projectId"].(string)
+	project, ok := s.nodes[projectID]
+	if !ok || project.Typename != "ProjectV2" {
+		return nil, nil, nil, notFound("Could not resolve to a ProjectV2 with the global id of '%s'", projectID)
+	}
+
+	itemID, _ := input["itemId"].(string)
+	item, ok := s.nodes[itemID]
+	if !ok || item.Typename != "ProjectV2Item" || item.Fields["project"] != project {
+		return nil, nil, nil, notFound("Could not resolve to a ProjectV2Item with the global id of '%s'", itemID)
+	}
+	if s.failingItems[itemID] {
+		return nil, nil, nil, fmt.Errorf("something went wrong while updating the item '%s'", itemID)
+	}
+
+	fieldID, _ := input["fieldId"].(string)
+	field := findField(project, "id", fieldID)
+	if field == nil {
+		return nil, nil, nil, notFound("Could not resolve to a ProjectV2Field with the global id of '%s'", fieldID)
+	}
+	return input, item, field, nil
+}
+
+func resolveUpdateProjectV2ItemFieldValue(s *Server, _ *object, args map[string]any) (any, error) {
+	input, item, field, err := s.mutationTarget(args)
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := input["value"].(map[string]any)
+	if !ok {
+		return nil, errors.New("argument 'value' is required")
+	}
+
+	var fieldValue *object
+	switch field.Typename {
+	case "ProjectV2IterationField":
+		fieldValue, err = newFieldValue(field, v["iterationId"])
+	case "ProjectV2SingleSelectField":
+		optionID, _ := v["singleSelectOptionId"].(string)
+		option := findOption(field, "id", optionID)
+		if option == nil {
+			return nil, fmt.Errorf("option not found: %s", optionID)
+		}
+		fieldValue, err = newFieldValue(field, option.Fields["name"])
+	default:
+		switch field.Fields["dataType"] {
+		case "NUMBER":
+			fieldValue, err = newFieldValue(field, v["number"])
+		case "DATE":
+			fieldValue, err = newFieldValue(field, v["date"])
+		default:
+			fieldValue, err = newFieldValue(field, v["text"])
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	setFieldValue(item, fieldValue)
+
+	return newObject("UpdateProjectV2ItemFieldValuePayload", map[string]any{
+		"clientMutationId": input["clientMutationId"],
+		"projectV2Item":    item,
+	}), nil
+}
+
+func resolveClearProjectV2ItemFieldValue(s *Server, _ *object, args map[string]any) (any, error) {
+	input, item, field, err := s.mutationTarget(args)
+	if err != nil {
+		return nil, err
+	}
+	clearFieldValue(item, field)
+
+	return newObject("ClearProjectV2ItemFieldValuePayload", map[string]any{
+		"clientMutationId": input["clientMutationId"],
+		"projectV2Item":    item,
+	}), nil
+}
+
+func findOption(field *object, key string, value any) *object {
+	options, _ := field.Fields["options"].([]*object)
+	for _, option := range options {
+		if option.Fields[key] == value {
+			return option
+		}
+	}
+	return nil
+}
+
+func findIteration(field *object, iterationID string) *object {
+	config, _ := field.Fields["configuration"].(*object)
+	for _, key := range []string{"iterations", "completedIterations"} {
+		iterations, _ := config.Fields[key].([]*object)
+		for _, iteration := range iterations {
+			if iteration.Fields["id"] == iterationID {
+				return iteration
+			}
+		}
+	}
+	return nil
+}
+
+// newFieldValue creates the value of the field from its notation in FixtureItem.FieldValues.
+func newFieldValue(field *object, v any) (*object, error) {
+	fields := map[string]any{"field": field}
+
+	switch field.Fields["dataType"] {
+	case "ITERATION":
+		iterationID, _ := v.(string)
+		iteration := findIteration(field, iterationID)
+		if iteration == nil {
+			return nil, fmt.Errorf("iteration not found: %v", v)
+		}
+		fields["iterationId"] = iterationID
+		for _, key := range []string{"title", "titleHTML", "startDate", "duration"} {
+			fields[key] = iteration.Fields[key]
+		}
+		return newObject("ProjectV2ItemFieldIterationValue", fields), nil
+	case "SINGLE_SELECT":
+		option := findOption(field, "name", v)
+		if option == nil {
+			return nil, fmt.Errorf("option not found: %v", v)
+		}
+		fields["name"] = option.Fields["name"]
+		fields["optionId"] = option.Fields["id"]
+		return newObject("ProjectV2ItemFieldSingleSelectValue", fields), nil
+	case "NUMBER":
+		number, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("not a number: %v", v)
+		}
+		fields["number"] = number
+		return newObject("ProjectV2ItemFieldNumberValue", fields), nil
+	case "DATE":
+		fields["date"] = v
+		return newObject("ProjectV2ItemFieldDateValue", fields), nil
+	case "TEXT", "TITLE":
+		fields["text"] = v
+		return newObject("ProjectV2ItemFieldTextValue", fields), nil
+	case "LABELS":
+		fields["labels"] = newConnection(v, func(name any) *object {
+			return newObject("Label", map[string]any{"name": name})
+		})
+		return newObject("ProjectV2ItemFieldLabelValue", fields), nil
+	case "ASSIGNEES":
+		fields["users"] = newConnection(v, func(login any) *object {
+			return newObject("User", map[string]any{"login": login})
+		})
+		return newObject("ProjectV2ItemFieldUserValue", fields), nil
+	case "REVIEWERS":
+		// A reviewer given as "org/slug" is a team.
+		fields["reviewers"] = newConnection(v, func(reviewer any) *object {
+			name, _ := reviewer.(string)
+			if org, slug, ok := strings.Cut(name, "/"); ok {
+				return newObject("Team", map[string]any{"slug": slug, "combinedSlug": org + "/" + slug})
+			}
+			return newObject("User", map[string]any{"login": name})
+		})
+		return newObject("ProjectV2ItemFieldReviewerValue", fields), nil
+	case "MILESTONE":
+		fields["milestone"] = newObject("Milestone", map[string]any{"title": v})
+		return newObject("ProjectV2ItemFieldMilestoneValue", fields), nil
+	case "REPOSITORY":
+		nameWithOwner, _ := v.(string)
+		fields["repository"] = newRepository(nameWithOwner)
+		return newObject("ProjectV2ItemFieldRepositoryValue", fields), nil
+	case "LINKED_PULL_REQUESTS":
+		fields["pullRequests"] = newConnection(v, func(number any) *object {
+			return newObject("PullRequest", map[string]any{"number": number})
+		})
+		return newObject("ProjectV2ItemFieldPullRequestValue", fields), nil
+	default:
+		return nil, fmt.Errorf("unsupported data type: %v", field.Fields["dataType"])
+	}
+}
+
+// newConnection builds a connection of the objects created from the elements of a list value.
+func newConnection(v any, newNode func(any) *object) connection {
+	list, _ := v.([]any)
+	nodes := make(connection, 0, len(list))
+	for _, e := range list {
+		nodes = append(nodes, newNode(e))
+	}
+	return nodes
+}
+
+// setFieldValue replaces the value of the field of the item.
+func setFieldValue(item *object, fieldValue *object) {
+	field, _ := fieldValue.Fields["field"].(*object)
+	values, _ := item.Fields["fieldValues"].(connection)
+	for i, v := range values {
+		if v.Fields["field"] == field {
+			values[i] = fieldValue
+			return
+		}
+	}
+	item.Fields["fieldValues"] = append(values, fieldValue)
+}
+
+func clearFieldValue(item *object, field *object) {
+	values, _ := item.Fields["fieldValues"].(connection)
+	cleared := make(connection, 0, len(values))
+	for _, v := range values {
+		if v.Fields["field"] != field {
+			cleared = append(cleared, v)
+		}
+	}
+	item.Fields["fieldValues"] = cleared
+}
+
+func resolveUpdateProjectV2Field(s *Server, _ *object, args map[string]any) (any, error) {
+	input, ok := args["input"].(map[string]any)
+	if !ok {
+		return nil, errInputRequired
+	}
+
+	fieldID, _ := input["fieldId"].(string)
+	field, ok := s.nodes[fieldID]
+	if !ok || !satisfies(field.Typename, "ProjectV2FieldCommon") {
+		return nil, notFound("Could not resolve to a ProjectV2Field with the global id of '%s'", fieldID)
+	}
+
+	if name, ok := input["name"].(string); ok {
+		field.Fields["name"] = name
+	}
+	if config, ok := input["iterationConfiguration"].(map[string]any); ok {
+		if field.Typename != "ProjectV2IterationField" {
+			return nil, errors.New("iteration configuration can only be set on an iteration field")
+		}
+		err := s.configureIterations(field, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newObject("UpdateProjectV2FieldPayload", map[string]any{
+		"clientMutationId": input["clientMutationId"],
+		"projectV2Field":   field,
+	}), nil
+}
+
+func resolveCreateProjectV2Field(s *Server, _ *object, args map[string]any) (any, error) {
+	input, ok := args["input"].(map[string]any)
+	if !ok {
+		return nil, errInputRequired
+	}
+
+	projectID, _ := input["projectId"].(string)
+	project, ok := s.nodes[projectID]
+	if !ok || project.Typename != "ProjectV2" {
+		return nil, notFound("Could not resolve to a ProjectV2 with the global id of '%s'", projectID)
+	}
+	name, _ := input["name"].(string)
+	if len(name) == 0 {
+		return nil, errors.New("name can't be blank")
+	}
+	if findField(project, "name", name) != nil {
+		return nil, fmt.Errorf("name has already been taken: %s", name)
+	}
+
+	dataType, _ := input["dataType"].(string)
+	var field *object
+	switch dataType {
+	case "ITERATION":
+		field = s.loadField(FixtureField{ //nolint:exhaustruct
+			ID: s.newID("PVTIF"), Name: name, DataType: dataType,
+		})
+		config, ok := input["iterationConfiguration"].(map[string]any)
+		if !ok {
+			return nil, errors.New("iteration configuration is required for an iteration field")
+		}
+		err := s.configureIterations(field, config)
+		if err != nil {
+			return nil, err
+		}
+	case "DATE", "NUMBER", "TEXT":
+		field = s.loadField(FixtureField{ //nolint:exhaustruct
+			ID: s.newID("PVTF"), Name: name, DataType: dataType,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported data type: %s", dataType)
+	}
+
+	fields, _ := project.Fields["fields"].(connection)
+	project.Fields["fields"] = append(fields, field)
+
+	return newObject("CreateProjectV2FieldPayload", map[string]any{
+		"clientMutationId": input["clientMutationId"],
+		"projectV2Field":   field,
+	}), nil
+}
+
+// configureIterations replaces the iterations of the field by the ones of the configuration input.
+// The ProjectV2Iteration input has no ID, so like the GitHub API, it gives new IDs to all the iterations,
+// which clears the field values of the project items.
+func (s *Server) configureIterations(field *object, config map[string]any) error {
+	startDate, _ := config["startDate"].(string)
+	duration, _ := config["duration"].(float64)
+	if _, err := time.Parse(time.DateOnly, startDate); err != nil {
+		return fmt.Errorf("invalid start date: %q", startDate)
+	}
+	if duration < 1 {
+		return fmt.Errorf("invalid duration: %v", config["duration"])
+	}
+
+	inputs, _ := config["iterations"].([]any)
+	iterations := make([]*object, 0, len(inputs))
+	for _, input := range inputs {
+		iteration, err := s.newIteration(input)
+		if err != nil {
+			return err
+		}
+		iterations = append(iterations, iteration)
+	}
+	slices.SortFunc(iterations, func(a, b *object) int {
+		return strings.Compare(a.Fields["startDate"].(string), b.Fields["startDate"].(string)) //nolint:forcetypeassert
+	})
+
+	var active, completed []*object
+	for _, iteration := range iterations {
+		start, _ := time.Parse(time.DateOnly, iteration.Fields["startDate"].(string)) //nolint:forcetypeassert
+		end := start.AddDate(0, 0, iteration.Fields["duration"].(int))                //nolint:forcetypeassert
+		if end.After(s.today) {
+			active = append(active, iteration)
+		} else {
+			completed = append([]*object{iteration}, completed...)
+		}
+	}
+
+	field.Fields["configuration"] = newObject("ProjectV2IterationFieldConfiguration", map[string]any{
+		"startDate":           startDate,
+		"duration":            int(duration),
+		"iterations":          active,
+		"completedIterations": completed,
+	})
+	s.refreshIterationValues(field)
+	return nil
+}
+
+func (s *Server) newIteration(input any) (*object, error) {
+	fields, _ := input.(map[string]any)
+	title, _ := fields["title"].(string)
+	startDate, _ := fields["startDate"].(string)
+	duration, _ := fields["duration"].(float64)
+	if _, err := time.Parse(time.DateOnly, startDate); err != nil {
+		return nil, fmt.Errorf("invalid start date of iteration %q: %q", title, startDate)
+	}
+	if len(title) == 0 || duration < 1 {
+		return nil, fmt.Errorf("invalid iteration: %v", input)
+	}
+
+	return newObject("ProjectV2IterationFieldIteration", map[string]any{
+		"id":        s.newID("iteration"),
+		"title":     title,
+		"titleHTML": title,
+		"startDate": startDate,
+		"duration":  int(duration),
+	}), nil
+}
+
+// refreshIterationValues updates the values of the field to the current iterations
+// and clears the values of the removed iterations.
+func (s *Server) refreshIterationValues(field *object) {
+	for _, node := range s.nodes {
+		if node.Typename != "ProjectV2Item" {
+			continue
+		}
+		values, _ := node.Fields["fieldValues"].(connection)
+		for _, v := range values {
+			if v.Fields["field"] != field {
+				continue
+			}
+			refreshed, err := newFieldValue(field, v.Fields["iterationId"])
+			if err != nil {
+				clearFieldValue(node, field)
+			} else {
+				setFieldValue(node, refreshed)
+			}
+		}
+	}
+}
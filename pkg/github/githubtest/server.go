@@ -0,0 +1,221 @@
+// Package githubtest provides a fake GitHub GraphQL API serving the projects of a fixture.
+//
+// The fake understands the queries and mutations issued by the github package,
+// so commands can be tested end to end without the network.
+package githubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+// Server is a fake GitHub GraphQL API backed by a fixture.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	nodes         map[string]*object
+	users         map[string]*object
+	organizations map[string]*object
+	viewer        *object
+	today         time.Time
+	lastID        int
+	operations    []string
+	rateLimited   int
+	failingItems  map[string]bool
+	// omitErrorPaths makes the server leave the paths out of the errors.
+	omitErrorPaths bool
+}
+
+// NewServer starts a fake server serving the fixture. It panics if the fixture is inconsistent.
+// The caller should call Close when finished, to shut it down.
+func NewServer(fixture *Fixture) *Server {
+	s := &Server{ //nolint:exhaustruct
+		nodes:         map[string]*object{},
+		users:         map[string]*object{},
+		organizations: map[string]*object{},
+		failingItems:  map[string]bool{},
+	}
+	err := s.load(fixture)
+	if err != nil {
+		panic(fmt.Errorf("githubtest: %w", err))
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveGraphQL))
+	return s
+}
+
+// NewClient creates a client sending its requests to the server.
+func (s *Server) NewClient() (*github.Client, error) {
+	client, err := github.NewClientWithOptions(api.ClientOptions{ //nolint:exhaustruct
+		Host:         "github.com",
+		AuthToken:    "githubtest",
+		Transport:    redirectTransport{target: s.URL, transport: s.Client().Transport},
+		LogIgnoreEnv: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("githubtest: %w", err)
+	}
+	return client, nil
+}
+
+// Operations returns the names of the operations received so far, in order.
+func (s *Server) Operations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.operations...)
+}
+
+// FieldValue returns the value of the field of a project item in the notation of FixtureItem.FieldValues,
+// or nil if the value is empty.
+func (s *Server) FieldValue(itemID string, fieldName string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.nodes[itemID]
+	if !ok {
+		return nil
+	}
+	values, _ := item.Fields["fieldValues"].(connection)
+	for _, v := range values {
+		field, _ := v.Fields["field"].(*object)
+		if field.Fields["name"] != fieldName {
+			continue
+		}
+		switch v.Typename {
+		case "ProjectV2ItemFieldIterationValue":
+			return v.Fields["iterationId"]
+		case "ProjectV2ItemFieldSingleSelectValue":
+			return v.Fields["name"]
+		case "ProjectV2ItemFieldNumberValue":
+			return v.Fields["number"]
+		case "ProjectV2ItemFieldTextValue":
+			return v.Fields["text"]
+		case "ProjectV2ItemFieldDateValue":
+			return v.Fields["date"]
+		}
+	}
+	return nil
+}
+
+// LimitRate makes the server reject the next n requests with a secondary rate limit error.
+// The error asks the client to retry immediately.
+func (s *Server) LimitRate(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimited = n
+}
+
+// FailItem makes the server reject the mutations of the project item.
+func (s *Server) FailItem(itemID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failingItems[itemID] = true
+}
+
+// OmitErrorPaths makes the server leave the paths out of the errors of the fields,
+// as GitHub does for some errors, so that they cannot be told apart by the aliases of a batch.
+func (s *Server) OmitErrorPaths() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.omitErrorPaths = true
+}
+
+func (s *Server) addNode(id string, obj *object) {
+	s.nodes[id] = obj
+}
+
+// newID generates an ID for an object created by a mutation.
+func (s *Server) newID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s_%d", prefix, s.lastID)
+}
+
+type request struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type response struct {
+	Data   any            `json:"data"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.takeRateLimit() {
+		w.Header().Set("Retry-After", "0")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "You have exceeded a secondary rate limit."})
+		return
+	}
+
+	var req request
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "problems parsing JSON", http.StatusBadRequest)
+		return
+	}
+
+	op, err := parseOperation(req.Query)
+	if err != nil {
+		writeJSON(w, response{Data: nil, Errors: []graphQLError{{Type: "", Message: err.Error(), Path: nil}}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.operations = append(s.operations, op.Name)
+	root := newObject("Query", map[string]any{})
+	if op.Type == "mutation" {
+		root.Typename = "Mutation"
+	}
+	e := &executor{server: s, variables: req.Variables, errors: nil}
+	data := e.selectionSet(root, op.Selections, nil)
+	writeJSON(w, response{Data: data, Errors: e.errors})
+}
+
+func (s *Server) takeRateLimit() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rateLimited == 0 {
+		return false
+	}
+	s.rateLimited--
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// redirectTransport sends every request to the target regardless of the requested host.
+type redirectTransport struct {
+	target    string
+	transport http.RoundTripper
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	return t.transport.RoundTrip(req) //nolint:wrapcheck
+}
@@ -0,0 +1,88 @@
+package githubtest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
+)
+
+func post(t *testing.T, server *githubtest.Server, query string, variables map[string]any) map[string]any {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]any
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestServerAliasesAndFragments(t *testing.T) {
+	t.Parallel()
+
+	server := githubtest.NewServer(githubtest.DefaultFixture())
+	t.Cleanup(server.Close)
+
+	query := `query Aliases($id: ID!) {
+		first: node(id: $id) { ... on ProjectV2Item { id, content { ... on Issue { number } } } }
+		second: node(id: "PVTI_4") { ... on ProjectV2Item { id, content { ... on Issue { number } ... on DraftIssue { title } } } }
+	}`
+	got := post(t, server, query, map[string]any{"id": "PVTI_1"})
+
+	want := map[string]any{
+		"data": map[string]any{
+			"first":  map[string]any{"id": "PVTI_1", "content": map[string]any{"number": float64(1)}},
+			"second": map[string]any{"id": "PVTI_4", "content": map[string]any{"title": "Write release notes"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if ops := server.Operations(); len(ops) != 1 || ops[0] != "Aliases" {
+		t.Errorf("wrong operations: %v", ops)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	t.Parallel()
+
+	server := githubtest.NewServer(githubtest.DefaultFixture())
+	t.Cleanup(server.Close)
+
+	got := post(t, server, `{ organization(login: "unknown") { id } user(login: "octocat") { login } }`, nil)
+
+	want := map[string]any{
+		"data": map[string]any{
+			"organization": nil,
+			"user":         map[string]any{"login": "octocat"},
+		},
+		"errors": []any{
+			map[string]any{
+				"type":    "NOT_FOUND",
+				"message": "Could not resolve to an Organization with the login of 'unknown'.",
+				"path":    []any{"organization"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
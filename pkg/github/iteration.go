@@ -3,7 +3,6 @@ package github
 import (
 	"fmt"
 
-	"github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 )
 
@@ -24,6 +23,8 @@ type ProjectV2IterationField struct {
 	Configuration struct {
 		CompletedIterations []ProjectV2IterationFieldIteration `json:"completedIterations"`
 		Iterations          []ProjectV2IterationFieldIteration `json:"iterations"`
+		Duration            int                                `json:"duration"`
+		StartDate           string                             `json:"startDate"`
 	} `json:"configuration"`
 }
 
@@ -32,12 +33,7 @@ type ProjectV2IterationFieldWithoutConfiguration struct {
 	Name string `json:"name"`
 }
 
-func FetchIterationFieldByName(projectID string, fieldName string) (*ProjectV2IterationField, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchIterationFieldByName(projectID string, fieldName string) (*ProjectV2IterationField, error) {
 	var query struct {
 		Node struct {
 			ProjectV2 struct {
@@ -49,10 +45,10 @@ func FetchIterationFieldByName(projectID string, fieldName string) (*ProjectV2It
 	}
 	variables := map[string]interface{}{
 		gqlVarProjectID: graphql.ID(projectID),
-		"field_name":    graphql.String(fieldName),
+		gqlVarFieldName: graphql.String(fieldName),
 	}
 
-	err = client.Query("IterationField", &query, variables)
+	err := c.gql.Query("IterationField", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a iteration field: %w", err)
 	}
@@ -60,12 +56,7 @@ func FetchIterationFieldByName(projectID string, fieldName string) (*ProjectV2It
 	return &query.Node.ProjectV2.Field.ProjectV2IterationField, nil
 }
 
-func FetchIterationFieldByID(fieldID string) (*ProjectV2IterationField, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchIterationFieldByID(fieldID string) (*ProjectV2IterationField, error) {
 	var query struct {
 		Node struct {
 			ProjectV2IterationField ProjectV2IterationField `graphql:"... on ProjectV2IterationField"`
@@ -75,7 +66,7 @@ func FetchIterationFieldByID(fieldID string) (*ProjectV2IterationField, error) {
 		"field_id": graphql.ID(fieldID),
 	}
 
-	err = client.Query("IterationField", &query, variables)
+	err := c.gql.Query("IterationField", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a iteration field: %w", err)
 	}
@@ -83,34 +74,39 @@ func FetchIterationFieldByID(fieldID string) (*ProjectV2IterationField, error) {
 	return &query.Node.ProjectV2IterationField, nil
 }
 
-func FetchIterationFields(projectID string) (*[]ProjectV2IterationFieldWithoutConfiguration, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
-	var query struct {
-		Node struct {
-			ProjectV2 struct {
-				Fields struct {
-					Nodes []struct {
-						ProjectV2IterationField ProjectV2IterationFieldWithoutConfiguration `graphql:"... on ProjectV2IterationField"`
-					} `graphql:"nodes"`
-				} `graphql:"fields(first: 100)"`
-			} `graphql:"... on ProjectV2"`
-		} `graphql:"node(id: $project_id)"`
-	}
-	variables := map[string]interface{}{
-		gqlVarProjectID: graphql.ID(projectID),
+func (c *Client) FetchIterationFields(projectID string) (*[]ProjectV2IterationFieldWithoutConfiguration, error) {
+	type fieldNode struct {
+		ProjectV2IterationField ProjectV2IterationFieldWithoutConfiguration `graphql:"... on ProjectV2IterationField"`
 	}
+	nodes, err := fetchAllPages(maxPageSize, 0, func(first int, after *graphql.String) ([]fieldNode, PageInfo, error) {
+		var query struct {
+			Node struct {
+				ProjectV2 struct {
+					Fields struct {
+						Nodes    []fieldNode `graphql:"nodes"`
+						PageInfo PageInfo    `graphql:"pageInfo"`
+					} `graphql:"fields(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $project_id)"`
+		}
+		variables := map[string]interface{}{
+			gqlVarProjectID: graphql.ID(projectID),
+			gqlVarFirst:     graphql.Int(first), //nolint:gosec
+			gqlVarAfter:     after,
+		}
 
-	err = client.Query("IterationFields", &query, variables)
+		err := c.gql.Query("IterationFields", &query, variables)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to retrieve a iteration fields: %w", err)
+		}
+		return query.Node.ProjectV2.Fields.Nodes, query.Node.ProjectV2.Fields.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve a iteration fields: %w", err)
+		return nil, err
 	}
 
 	var fields []ProjectV2IterationFieldWithoutConfiguration
-	for _, field := range query.Node.ProjectV2.Fields.Nodes {
+	for _, field := range nodes {
 		if len(field.ProjectV2IterationField.ID) > 0 {
 			fields = append(fields, field.ProjectV2IterationField)
 		}
@@ -119,11 +115,7 @@ func FetchIterationFields(projectID string) (*[]ProjectV2IterationFieldWithoutCo
 	return &fields, nil
 }
 
-func UpdateIterationField(projectID string, fieldID string, itemID string, iterationID string) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
+func (c *Client) UpdateIterationField(projectID string, fieldID string, itemID string, iterationID string) (string, error) {
 	type ProjectV2Item struct {
 		ID string `graphql:"id"`
 	}
@@ -153,7 +145,7 @@ func UpdateIterationField(projectID string, fieldID string, itemID string, itera
 			Value:     ProjectV2FieldValue{IterationID: iterationID},
 		},
 	}
-	err = client.Mutate("updateProjectV2ItemFieldValue", &mutation, variables)
+	err := c.gql.Mutate("updateProjectV2ItemFieldValue", &mutation, variables)
 	if err != nil {
 		return "", fmt.Errorf("failed to update the iteration field: %w", err)
 	}
@@ -162,11 +154,7 @@ func UpdateIterationField(projectID string, fieldID string, itemID string, itera
 }
 
 // https://docs.github.com/ja/graphql/reference/mutations#clearprojectv2itemfieldvalue
-func ClearIterationField(projectID string, fieldID string, itemID string) (string, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return "", fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
+func (c *Client) ClearIterationField(projectID string, fieldID string, itemID string) (string, error) {
 	type ProjectV2Item struct {
 		ID string `graphql:"id"`
 	}
@@ -191,10 +179,97 @@ func ClearIterationField(projectID string, fieldID string, itemID string) (strin
 			ProjectID: projectID,
 		},
 	}
-	err = client.Mutate("clearProjectV2ItemFieldValue", &mutation, variables)
+	err := c.gql.Mutate("clearProjectV2ItemFieldValue", &mutation, variables)
 	if err != nil {
 		return "", fmt.Errorf("failed to clear the iteration field: %w", err)
 	}
 
 	return mutation.ClearProjectV2ItemFieldValue.ProjectV2Item.ID, nil
 }
+
+// ProjectV2Iteration
+// https://docs.github.com/en/graphql/reference/input-objects#projectv2iteration
+type ProjectV2Iteration struct {
+	Duration  int    `json:"duration"`
+	StartDate string `json:"startDate"`
+	Title     string `json:"title"`
+}
+
+// ProjectV2IterationFieldConfigurationInput
+// https://docs.github.com/en/graphql/reference/input-objects#projectv2iterationfieldconfigurationinput
+type ProjectV2IterationFieldConfigurationInput struct {
+	Duration   int                  `json:"duration"`
+	Iterations []ProjectV2Iteration `json:"iterations"`
+	StartDate  string               `json:"startDate"`
+}
+
+// UpdateIterationFieldConfiguration replaces the iterations of an iteration field.
+// The iterations in the configuration must include the completed ones to keep them.
+// https://docs.github.com/en/graphql/reference/mutations#updateprojectv2field
+func (c *Client) UpdateIterationFieldConfiguration(
+	fieldID string, configuration ProjectV2IterationFieldConfigurationInput,
+) (*ProjectV2IterationField, error) {
+	var mutation struct {
+		UpdateProjectV2Field struct {
+			ClientMutationID string `graphql:"clientMutationId"`
+			ProjectV2Field   struct {
+				ProjectV2IterationField ProjectV2IterationField `graphql:"... on ProjectV2IterationField"`
+			} `graphql:"projectV2Field"`
+		} `graphql:"updateProjectV2Field(input: $input)"`
+	}
+	// https://docs.github.com/en/graphql/reference/input-objects#updateprojectv2fieldinput
+	type UpdateProjectV2FieldInput struct {
+		FieldID                string                                    `json:"fieldId"`
+		IterationConfiguration ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration"`
+	}
+
+	variables := map[string]interface{}{
+		"input": UpdateProjectV2FieldInput{
+			FieldID:                fieldID,
+			IterationConfiguration: configuration,
+		},
+	}
+	err := c.gql.Mutate("updateProjectV2Field", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the iteration field configuration: %w", err)
+	}
+
+	return &mutation.UpdateProjectV2Field.ProjectV2Field.ProjectV2IterationField, nil
+}
+
+// CreateIterationField creates an iteration field with the configuration in a project.
+// https://docs.github.com/en/graphql/reference/mutations#createprojectv2field
+func (c *Client) CreateIterationField(
+	projectID string, name string, configuration ProjectV2IterationFieldConfigurationInput,
+) (*ProjectV2IterationField, error) {
+	var mutation struct {
+		CreateProjectV2Field struct {
+			ClientMutationID string `graphql:"clientMutationId"`
+			ProjectV2Field   struct {
+				ProjectV2IterationField ProjectV2IterationField `graphql:"... on ProjectV2IterationField"`
+			} `graphql:"projectV2Field"`
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+	// https://docs.github.com/en/graphql/reference/input-objects#createprojectv2fieldinput
+	type CreateProjectV2FieldInput struct {
+		DataType               string                                    `json:"dataType"`
+		IterationConfiguration ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration"`
+		Name                   string                                    `json:"name"`
+		ProjectID              string                                    `json:"projectId"`
+	}
+
+	variables := map[string]interface{}{
+		"input": CreateProjectV2FieldInput{
+			DataType:               "ITERATION",
+			IterationConfiguration: configuration,
+			Name:                   name,
+			ProjectID:              projectID,
+		},
+	}
+	err := c.gql.Mutate("createProjectV2Field", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the iteration field: %w", err)
+	}
+
+	return &mutation.CreateProjectV2Field.ProjectV2Field.ProjectV2IterationField, nil
+}
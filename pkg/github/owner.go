@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 )
 
@@ -25,9 +24,9 @@ type Owner struct {
 	Type  OwnerType `json:"type"`
 }
 
-func FetchOwnerByLogin(login string) (*Owner, error) {
+func (c *Client) FetchOwnerByLogin(login string) (*Owner, error) {
 	if len(login) == 0 || login == "@me" {
-		viewer, err := FetchUserByViewer()
+		viewer, err := c.FetchUserByViewer()
 		if err != nil {
 			return nil, err
 		}
@@ -39,11 +38,6 @@ func FetchOwnerByLogin(login string) (*Owner, error) {
 		}, nil
 	}
 
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
 	var query struct {
 		Organization Organization `graphql:"organization(login: $login)"`
 		User         User         `graphql:"user(login: $login)"`
@@ -52,7 +46,7 @@ func FetchOwnerByLogin(login string) (*Owner, error) {
 		gqlVarLogin: graphql.String(login),
 	}
 
-	err = client.Query("OrgOrUser", &query, variables)
+	err := c.gql.Query("OrgOrUser", &query, variables)
 
 	if len(query.User.Login) > 0 {
 		return &Owner{
@@ -84,12 +78,7 @@ type Organization struct {
 	Name  string `json:"name"`
 }
 
-func FetchOrganizationByLogin(login string) (*Organization, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchOrganizationByLogin(login string) (*Organization, error) {
 	var query struct {
 		Organization Organization `graphql:"organization(login: $login)"`
 	}
@@ -97,7 +86,7 @@ func FetchOrganizationByLogin(login string) (*Organization, error) {
 		gqlVarLogin: graphql.String(login),
 	}
 
-	err = client.Query("Organization", &query, variables)
+	err := c.gql.Query("Organization", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a organization: %w", err)
 	}
@@ -113,12 +102,7 @@ type User struct {
 	Name  string `json:"name"`
 }
 
-func FetchUserByLogin(login string) (*User, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchUserByLogin(login string) (*User, error) {
 	var query struct {
 		User User `graphql:"user(login: $login)"`
 	}
@@ -126,7 +110,7 @@ func FetchUserByLogin(login string) (*User, error) {
 		gqlVarLogin: graphql.String(login),
 	}
 
-	err = client.Query("User", &query, variables)
+	err := c.gql.Query("User", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a user: %w", err)
 	}
@@ -134,17 +118,12 @@ func FetchUserByLogin(login string) (*User, error) {
 	return &query.User, nil
 }
 
-func FetchUserByViewer() (*User, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchUserByViewer() (*User, error) {
 	var query struct {
 		User User `graphql:"viewer"`
 	}
 
-	err = client.Query("Viewer", &query, nil)
+	err := c.gql.Query("Viewer", &query, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a user: %w", err)
 	}
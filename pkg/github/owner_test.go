@@ -29,7 +29,7 @@ func TestFetchOwnerIDByLogin(t *testing.T) {
 			t.Parallel()
 
 			login := test.login
-			owner, err := github.FetchOwnerByLogin(login)
+			owner, err := newClient(t).FetchOwnerByLogin(login)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -53,7 +53,7 @@ func TestFetchOrganizationByLogin(t *testing.T) {
 	t.Parallel()
 
 	login := testOrgLogin
-	org, err := github.FetchOrganizationByLogin(login)
+	org, err := newClient(t).FetchOrganizationByLogin(login)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -72,7 +72,7 @@ func TestFetchUserByLogin(t *testing.T) {
 	t.Parallel()
 
 	login := testUserLogin
-	org, err := github.FetchUserByLogin(login)
+	org, err := newClient(t).FetchUserByLogin(login)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -94,7 +94,7 @@ func TestFetchUserByViewer(t *testing.T) {
 	if os.Getenv("CI") == "true" {
 		login = "github-actions[bot]"
 	}
-	owner, err := github.FetchUserByViewer()
+	owner, err := newClient(t).FetchUserByViewer()
 	if err != nil {
 		t.Fatal(err)
 	}
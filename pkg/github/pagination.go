@@ -0,0 +1,52 @@
+package github
+
+import (
+	"fmt"
+
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// maxPageSize is the maximum number of nodes GitHub returns for a page of a connection.
+const maxPageSize = 100
+
+// PageInfo
+// https://docs.github.com/en/graphql/reference/objects#pageinfo
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// pageFetcher retrieves a page of at most first nodes after the cursor (nil for the first page).
+type pageFetcher[T any] func(first int, after *graphql.String) ([]T, PageInfo, error)
+
+// fetchAllPages calls fetch with the end cursor of the previous page until the connection has no next page.
+// If limit is positive, it stops after limit nodes have been collected.
+func fetchAllPages[T any](pageSize int, limit int, fetch pageFetcher[T]) ([]T, error) {
+	if pageSize < 1 || pageSize > maxPageSize {
+		return nil, fmt.Errorf("page size must be between 1 and %d: %d", maxPageSize, pageSize)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative: %d", limit)
+	}
+
+	nodes := []T{}
+	var cursor *graphql.String
+	for {
+		first := pageSize
+		if limit > 0 {
+			first = min(first, limit-len(nodes))
+		}
+
+		page, pageInfo, err := fetch(first, cursor)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, page...)
+
+		if !pageInfo.HasNextPage || (limit > 0 && len(nodes) >= limit) {
+			return nodes, nil
+		}
+		endCursor := graphql.String(pageInfo.EndCursor)
+		cursor = &endCursor
+	}
+}
@@ -0,0 +1,5 @@
+package github
+
+var FetchAllPages = fetchAllPages[int] //nolint:gochecknoglobals
+
+type PageFetcher = pageFetcher[int]
@@ -0,0 +1,111 @@
+package github_test
+
+import (
+	"strconv"
+	"testing"
+
+	graphql "github.com/cli/shurcooL-graphql"
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+// newPageFetcher returns a fetcher serving the integers 0 to total-1 and records the requested page sizes.
+func newPageFetcher(t *testing.T, total int, requested *[]int) github.PageFetcher {
+	t.Helper()
+
+	return func(first int, after *graphql.String) ([]int, github.PageInfo, error) {
+		*requested = append(*requested, first)
+		start := 0
+		if after != nil {
+			n, err := strconv.Atoi(string(*after))
+			if err != nil {
+				t.Fatal(err)
+			}
+			start = n
+		}
+		end := min(start+first, total)
+		nodes := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			nodes = append(nodes, i)
+		}
+		return nodes, github.PageInfo{HasNextPage: end < total, EndCursor: strconv.Itoa(end)}, nil
+	}
+}
+
+func TestFetchAllPages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		total     int
+		pageSize  int
+		limit     int
+		wantLen   int
+		wantPages []int
+	}{
+		{"empty", 0, 100, 0, 0, []int{100}},
+		{"single page", 30, 100, 0, 30, []int{100}},
+		{"multiple pages", 250, 100, 0, 250, []int{100, 100, 100}},
+		{"limit across pages", 250, 100, 150, 150, []int{100, 50}},
+		{"limit on page boundary", 250, 50, 100, 100, []int{50, 50}},
+		{"limit above total", 30, 10, 100, 30, []int{10, 10, 10}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requested []int
+			nodes, err := github.FetchAllPages(test.pageSize, test.limit, newPageFetcher(t, test.total, &requested))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(nodes) != test.wantLen {
+				t.Errorf("wrong number of nodes want: %d, got %d", test.wantLen, len(nodes))
+			}
+			for i, node := range nodes {
+				if node != i {
+					t.Errorf("wrong node at %d: got %d", i, node)
+				}
+			}
+			if len(requested) != len(test.wantPages) {
+				t.Fatalf("wrong number of requests want: %v, got %v", test.wantPages, requested)
+			}
+			for i := range requested {
+				if requested[i] != test.wantPages[i] {
+					t.Errorf("wrong page sizes want: %v, got %v", test.wantPages, requested)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchAllPagesInvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pageSize int
+		limit    int
+	}{
+		{"zero page size", 0, 0},
+		{"too large page size", 101, 0},
+		{"negative limit", 100, -1},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requested []int
+			_, err := github.FetchAllPages(test.pageSize, test.limit, newPageFetcher(t, 10, &requested))
+			if err == nil {
+				t.Error("want error, got nil")
+			}
+			if len(requested) > 0 {
+				t.Errorf("want no requests, got %v", requested)
+			}
+		})
+	}
+}
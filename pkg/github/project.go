@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"math"
 
-	"github.com/cli/go-gh/v2/pkg/api"
 	graphql "github.com/cli/shurcooL-graphql"
 )
 
-// gqlVarProjectID is the GraphQL variable name for a project node ID.
-const gqlVarProjectID = "project_id"
+// GraphQL variable names shared by the queries in this package.
+const (
+	gqlVarProjectID = "project_id"
+	gqlVarItemID    = "item_id"
+	gqlVarFieldName = "field_name"
+	gqlVarFirst     = "first"
+	gqlVarAfter     = "after"
+)
 
 // Project
 // https://docs.github.com/en/graphql/reference/objects#projectv2
@@ -19,14 +24,10 @@ type Project struct {
 	Title  string `json:"title"`
 }
 
-func FetchProjectByNumber(number int, ownerID string) (*Project, error) {
+func (c *Client) FetchProjectByNumber(number int, ownerID string) (*Project, error) {
 	if number < math.MinInt32 || number > math.MaxInt32 {
 		return nil, fmt.Errorf("project number is out of range: %d", number)
 	}
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
 
 	var query struct {
 		Node struct {
@@ -40,19 +41,14 @@ func FetchProjectByNumber(number int, ownerID string) (*Project, error) {
 		"number":   graphql.Int(number),
 	}
 
-	err = client.Query("Project", &query, variables)
+	err := c.gql.Query("Project", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve a project: %w", err)
 	}
 	return &query.Node.ProjectV2Owner.ProjectV2, nil
 }
 
-func FetchProjectByID(projectID string) (*Project, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchProjectByID(projectID string) (*Project, error) {
 	var query struct {
 		Node struct {
 			ProjectV2 Project `graphql:"... on ProjectV2"`
@@ -62,7 +58,7 @@ func FetchProjectByID(projectID string) (*Project, error) {
 		gqlVarProjectID: graphql.ID(projectID),
 	}
 
-	err = client.Query("ProjectId", &query, variables)
+	err := c.gql.Query("ProjectId", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch ProjectV2 by project id: %w", err)
 	}
@@ -79,30 +75,35 @@ type ProjectV2FieldConfiguration struct {
 	// ProjectV2SingleSelectField struct{} `graphql:"... on ProjectV2SingleSelectField"`
 }
 
-func FetchProjectFields(projectID string) (*[]ProjectV2FieldConfiguration, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
+func (c *Client) FetchProjectFields(projectID string) (*[]ProjectV2FieldConfiguration, error) {
+	fields, err := fetchAllPages(maxPageSize, 0,
+		func(first int, after *graphql.String) ([]ProjectV2FieldConfiguration, PageInfo, error) {
+			var query struct {
+				Node struct {
+					ProjectV2 struct {
+						Fields struct {
+							Nodes    []ProjectV2FieldConfiguration `graphql:"nodes"`
+							PageInfo PageInfo                      `graphql:"pageInfo"`
+						} `graphql:"fields(first: $first, after: $after)"`
+					} `graphql:"... on ProjectV2"`
+				} `graphql:"node(id: $project_id)"`
+			}
+			variables := map[string]interface{}{
+				gqlVarProjectID: graphql.ID(projectID),
+				gqlVarFirst:     graphql.Int(first), //nolint:gosec
+				gqlVarAfter:     after,
+			}
 
-	var query struct {
-		Node struct {
-			ProjectV2 struct {
-				Fields struct {
-					Nodes []ProjectV2FieldConfiguration `graphql:"nodes"`
-				} `graphql:"fields(first: 100)"`
-			} `graphql:"... on ProjectV2"`
-		} `graphql:"node(id: $project_id)"`
-	}
-	variables := map[string]interface{}{
-		gqlVarProjectID: graphql.ID(projectID),
-	}
-
-	err = client.Query("ProjectV2FieldConfiguration", &query, variables)
+			err := c.gql.Query("ProjectV2FieldConfiguration", &query, variables)
+			if err != nil {
+				return nil, PageInfo{}, fmt.Errorf("failed to fetch ProjectV2FieldConfiguration by project id: %w", err)
+			}
+			return query.Node.ProjectV2.Fields.Nodes, query.Node.ProjectV2.Fields.PageInfo, nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch ProjectV2FieldConfiguration by project id: %w", err)
+		return nil, err
 	}
-	return &query.Node.ProjectV2.Fields.Nodes, nil
+	return &fields, nil
 }
 
 // ProjectV2Item
@@ -111,20 +112,56 @@ type ProjectItem struct {
 	Content     ProjectItemContent `json:"content"`
 	ID          string             `json:"id"`
 	FieldValues struct {
-		Nodes []FieldValue `json:"nodes"`
+		Nodes    []FieldValue `json:"nodes"`
+		PageInfo PageInfo     `json:"pageInfo"`
 	} `json:"fieldValues" graphql:"fieldValues(first: 100)"`
 	IsArchived bool    `json:"isArchived"`
 	Type       string  `json:"type"` // DRAFT_ISSUE, ISSUE, PULL_REQUEST, REDACTED
 	Project    Project `json:"project"`
 }
 
+// Connection is a page of the nodes of a connection.
+// https://docs.github.com/en/graphql/guides/using-pagination-in-the-graphql-api
+type Connection[T any] struct {
+	Nodes    []T      `json:"nodes"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+type labelNode struct {
+	Name string `json:"name"`
+}
+
+type pullRequestNode struct {
+	Number int `json:"number"`
+}
+
+// reviewerNode is a union of the requested reviewers.
+// https://docs.github.com/en/graphql/reference/unions#requestedreviewer
+type reviewerNode struct {
+	User struct {
+		Login string `json:"login"`
+	} `graphql:"... on User"`
+	Team struct {
+		CombinedSlug string `json:"combinedSlug"`
+	} `graphql:"... on Team"`
+}
+
+type userNode struct {
+	Login string `json:"login"`
+}
+
+// FieldValue is a union of the field values of a project item.
+// Its labels, pull requests, reviewers and users are requested 10 at a time, since larger pages with the pages
+// of items and field values would exceed the node limit of GitHub. The remaining ones are fetched afterwards.
+// https://docs.github.com/en/graphql/overview/rate-limits-and-node-limits-for-the-graphql-api#node-limit
+// https://docs.github.com/en/graphql/reference/unions#projectv2itemfieldvalue
 type FieldValue struct {
 	ProjectV2ItemFieldValueCommon struct {
 		Field ProjectV2FieldConfiguration `json:"field"`
 	} `graphql:"... on ProjectV2ItemFieldValueCommon"`
 	ProjectV2ItemFieldDateValue struct {
 		Field ProjectV2FieldConfiguration `json:"field"`
-		// Date  string
+		Date  string                      `json:"date"`
 	} `graphql:"... on ProjectV2ItemFieldDateValue"`
 	ProjectV2ItemFieldIterationValue struct {
 		Field       ProjectV2FieldConfiguration `json:"field"`
@@ -135,23 +172,30 @@ type FieldValue struct {
 		TitleHTML   string                      `graphql:"titleHTML" json:"titleHtml"`
 	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
 	ProjectV2ItemFieldLabelValue struct {
-		Field ProjectV2FieldConfiguration `json:"field"`
+		Field  ProjectV2FieldConfiguration `json:"field"`
+		Labels Connection[labelNode]       `graphql:"labels(first: 10)" json:"labels"`
 	} `graphql:"... on ProjectV2ItemFieldLabelValue"`
 	ProjectV2ItemFieldMilestoneValue struct {
-		Field ProjectV2FieldConfiguration `json:"field"`
+		Field     ProjectV2FieldConfiguration `json:"field"`
+		Milestone struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
 	} `graphql:"... on ProjectV2ItemFieldMilestoneValue"`
 	ProjectV2ItemFieldNumberValue struct {
 		Field  ProjectV2FieldConfiguration `json:"field"`
 		Number graphql.Float               `json:"number"`
 	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
 	ProjectV2ItemFieldPullRequestValue struct {
-		Field ProjectV2FieldConfiguration `json:"field"`
+		Field        ProjectV2FieldConfiguration `json:"field"`
+		PullRequests Connection[pullRequestNode] `graphql:"pullRequests(first: 10)" json:"pullRequests"`
 	} `graphql:"... on ProjectV2ItemFieldPullRequestValue"`
 	ProjectV2ItemFieldRepositoryValue struct {
-		Field ProjectV2FieldConfiguration `json:"field"`
+		Field      ProjectV2FieldConfiguration `json:"field"`
+		Repository Repository                  `json:"repository"`
 	} `graphql:"... on ProjectV2ItemFieldRepositoryValue"`
 	ProjectV2ItemFieldReviewerValue struct {
-		Field ProjectV2FieldConfiguration `json:"field"`
+		Field     ProjectV2FieldConfiguration `json:"field"`
+		Reviewers Connection[reviewerNode]    `graphql:"reviewers(first: 10)" json:"reviewers"`
 	} `graphql:"... on ProjectV2ItemFieldReviewerValue"`
 	ProjectV2ItemFieldSingleSelectValue struct {
 		Field    ProjectV2FieldConfiguration `json:"field"`
@@ -160,12 +204,34 @@ type FieldValue struct {
 	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
 	ProjectV2ItemFieldTextValue struct {
 		Field ProjectV2FieldConfiguration `json:"field"`
+		Text  string                      `json:"text"`
 	} `graphql:"... on ProjectV2ItemFieldTextValue"`
 	ProjectV2ItemFieldUserValue struct {
 		Field ProjectV2FieldConfiguration `json:"field"`
+		Users Connection[userNode]        `graphql:"users(first: 10)" json:"users"`
 	} `graphql:"... on ProjectV2ItemFieldUserValue"`
 }
 
+// Field returns the field of the value.
+// The values of labels, milestones, pull requests, repositories, reviewers and users
+// do not implement ProjectV2ItemFieldValueCommon, so the field is taken from the matched type.
+func (v *FieldValue) Field() ProjectV2FieldConfiguration {
+	for _, field := range []ProjectV2FieldConfiguration{
+		v.ProjectV2ItemFieldValueCommon.Field,
+		v.ProjectV2ItemFieldLabelValue.Field,
+		v.ProjectV2ItemFieldMilestoneValue.Field,
+		v.ProjectV2ItemFieldPullRequestValue.Field,
+		v.ProjectV2ItemFieldRepositoryValue.Field,
+		v.ProjectV2ItemFieldReviewerValue.Field,
+		v.ProjectV2ItemFieldUserValue.Field,
+	} {
+		if len(field.ProjectV2FieldCommon.Name) > 0 {
+			return field
+		}
+	}
+	return v.ProjectV2ItemFieldValueCommon.Field
+}
+
 // https://docs.github.com/ja/graphql/reference/unions#projectv2itemcontent
 type ProjectItemContent struct {
 	DraftIssue  DraftIssue  `graphql:"...on DraftIssue"  json:"draftIssue"`
@@ -175,8 +241,11 @@ type ProjectItemContent struct {
 
 // https://docs.github.com/ja/graphql/reference/objects#draftissue
 type DraftIssue struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Creator   Actor  `json:"creator"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
 }
 
 // https://docs.github.com/ja/graphql/reference/objects#issue
@@ -184,10 +253,15 @@ type Issue struct {
 	ID          string     `json:"id"`
 	Number      int        `json:"number"`
 	Title       string     `json:"title"`
+	URL         string     `json:"url"`
 	Closed      bool       `json:"closed"`
 	Repository  Repository `json:"repository"`
 	State       string     `json:"state"`       // CLOSED, OPEN
 	StateReason string     `json:"stateReason"` // COMPLETED, NOT_PLANNED, REOPENED
+	Author      Actor      `json:"author"`
+	CreatedAt   string     `json:"createdAt"`
+	UpdatedAt   string     `json:"updatedAt"`
+	ClosedAt    string     `json:"closedAt"`
 }
 
 // https://docs.github.com/ja/graphql/reference/objects#pullrequest
@@ -195,11 +269,21 @@ type PullRequest struct {
 	ID         string     `json:"id"`
 	Number     int        `json:"number"`
 	Title      string     `json:"title"`
+	URL        string     `json:"url"`
 	Closed     bool       `json:"closed"`
 	IsDraft    bool       `json:"isDraft"`
 	Merged     bool       `json:"merged"`
 	Repository Repository `json:"repository"`
 	State      string     `json:"state"` // CLOSED, MERGED, OPEN
+	Author     Actor      `json:"author"`
+	CreatedAt  string     `json:"createdAt"`
+	UpdatedAt  string     `json:"updatedAt"`
+	ClosedAt   string     `json:"closedAt"`
+}
+
+// https://docs.github.com/en/graphql/reference/interfaces#actor
+type Actor struct {
+	Login string `json:"login"`
 }
 
 // https://docs.github.com/ja/graphql/reference/objects#repository
@@ -209,51 +293,205 @@ type Repository struct {
 	// Owner         string `json:"owner"`
 }
 
-func FetchProjectItem(itemID string) (*ProjectItem, error) {
-	client, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
-	}
-
+func (c *Client) FetchProjectItem(itemID string) (*ProjectItem, error) {
 	var query struct {
 		Node struct {
 			ProjectV2Item ProjectItem `graphql:"... on ProjectV2Item"`
 		} `graphql:"node(id: $item_id)"`
 	}
 	variables := map[string]interface{}{
-		"item_id": graphql.ID(itemID),
+		gqlVarItemID: graphql.ID(itemID),
 	}
 
-	err = client.Query("ProjectItem", &query, variables)
+	err := c.gql.Query("ProjectItem", &query, variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch ProjectV2Item by item id: %w", err)
 	}
 
-	return &query.Node.ProjectV2Item, nil
+	item := &query.Node.ProjectV2Item
+	err = c.fetchRemainingFieldValues(item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
 }
 
-func FetchProjectItems(projectID string) (*[]ProjectItem, error) {
-	client, err := api.DefaultGraphQLClient()
+// DefaultItemsPageSize is the number of project items requested per page.
+const DefaultItemsPageSize = maxPageSize
+
+// FetchProjectItems retrieves the items of a project by following the cursor of the items connection.
+// pageSize is the number of items requested per page (1 to 100).
+// limit is the maximum number of items to retrieve, or 0 to retrieve all of them.
+func (c *Client) FetchProjectItems(projectID string, pageSize int, limit int) (*[]ProjectItem, error) {
+	items, err := fetchAllPages(pageSize, limit, func(first int, after *graphql.String) ([]ProjectItem, PageInfo, error) {
+		var query struct {
+			Node struct {
+				ProjectV2 struct {
+					Items struct {
+						Nodes    []ProjectItem `graphql:"nodes"`
+						PageInfo PageInfo      `graphql:"pageInfo"`
+					} `graphql:"items(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $project_id)"`
+		}
+		variables := map[string]interface{}{
+			gqlVarProjectID: graphql.ID(projectID),
+			gqlVarFirst:     graphql.Int(first), //nolint:gosec
+			gqlVarAfter:     after,
+		}
+
+		err := c.gql.Query("ProjectItems", &query, variables)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to fetch ProjectItems by project id: %w", err)
+		}
+		return query.Node.ProjectV2.Items.Nodes, query.Node.ProjectV2.Items.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to init GraphQL client: %w", err)
+		return nil, err
 	}
 
-	var query struct {
-		Node struct {
-			ProjectV2 struct {
-				Items struct {
-					Nodes []ProjectItem `graphql:"nodes"`
-				} `graphql:"items(first: 100)"`
-			} `graphql:"... on ProjectV2"`
-		} `graphql:"node(id: $project_id)"`
+	for i := range items {
+		err = c.fetchRemainingFieldValues(&items[i])
+		if err != nil {
+			return nil, err
+		}
 	}
-	variables := map[string]interface{}{
-		gqlVarProjectID: graphql.ID(projectID),
+	return &items, nil
+}
+
+// fetchRemainingFieldValues appends the field values beyond the first page to the item,
+// and the labels, pull requests, reviewers and users beyond the first page to its field values.
+func (c *Client) fetchRemainingFieldValues(item *ProjectItem) error {
+	if !item.FieldValues.PageInfo.HasNextPage {
+		return c.fetchRemainingFieldValueNodes(item)
+	}
+
+	cursor := graphql.String(item.FieldValues.PageInfo.EndCursor)
+	fieldValues, err := fetchAllPages(maxPageSize, 0, func(first int, after *graphql.String) ([]FieldValue, PageInfo, error) {
+		if after == nil {
+			after = &cursor
+		}
+
+		var query struct {
+			Node struct {
+				ProjectV2Item struct {
+					FieldValues struct {
+						Nodes    []FieldValue `graphql:"nodes"`
+						PageInfo PageInfo     `graphql:"pageInfo"`
+					} `graphql:"fieldValues(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2Item"`
+			} `graphql:"node(id: $item_id)"`
+		}
+		variables := map[string]interface{}{
+			gqlVarItemID: graphql.ID(item.ID),
+			gqlVarFirst:  graphql.Int(first), //nolint:gosec
+			gqlVarAfter:  after,
+		}
+
+		err := c.gql.Query("ProjectItemFieldValues", &query, variables)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to fetch field values of ProjectV2Item: %w", err)
+		}
+		return query.Node.ProjectV2Item.FieldValues.Nodes, query.Node.ProjectV2Item.FieldValues.PageInfo, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	item.FieldValues.Nodes = append(item.FieldValues.Nodes, fieldValues...)
+	item.FieldValues.PageInfo = PageInfo{HasNextPage: false, EndCursor: ""}
+	return c.fetchRemainingFieldValueNodes(item)
+}
+
+// fieldValueNodesQuery retrieves a page of the labels, pull requests, reviewers or users of a field value.
+type fieldValueNodesQuery struct {
+	Node struct {
+		ProjectV2Item struct {
+			FieldValueByName struct {
+				ProjectV2ItemFieldLabelValue struct {
+					Labels Connection[labelNode] `graphql:"labels(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2ItemFieldLabelValue"`
+				ProjectV2ItemFieldPullRequestValue struct {
+					PullRequests Connection[pullRequestNode] `graphql:"pullRequests(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2ItemFieldPullRequestValue"`
+				ProjectV2ItemFieldReviewerValue struct {
+					Reviewers Connection[reviewerNode] `graphql:"reviewers(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2ItemFieldReviewerValue"`
+				ProjectV2ItemFieldUserValue struct {
+					Users Connection[userNode] `graphql:"users(first: $first, after: $after)"`
+				} `graphql:"... on ProjectV2ItemFieldUserValue"`
+			} `graphql:"fieldValueByName(name: $field_name)"`
+		} `graphql:"... on ProjectV2Item"`
+	} `graphql:"node(id: $item_id)"`
+}
+
+// fetchRemainingFieldValueNodes appends the labels, pull requests, reviewers and users beyond the first page
+// to the field values of the item.
+func (c *Client) fetchRemainingFieldValueNodes(item *ProjectItem) error {
+	for i := range item.FieldValues.Nodes {
+		v := &item.FieldValues.Nodes[i]
+		fieldName := v.Field().ProjectV2FieldCommon.Name
+		var err error
+		switch {
+		case v.ProjectV2ItemFieldLabelValue.Labels.PageInfo.HasNextPage:
+			err = fetchRemainingNodes(c, item.ID, fieldName, &v.ProjectV2ItemFieldLabelValue.Labels,
+				func(q *fieldValueNodesQuery) Connection[labelNode] {
+					return q.Node.ProjectV2Item.FieldValueByName.ProjectV2ItemFieldLabelValue.Labels
+				})
+		case v.ProjectV2ItemFieldPullRequestValue.PullRequests.PageInfo.HasNextPage:
+			err = fetchRemainingNodes(c, item.ID, fieldName, &v.ProjectV2ItemFieldPullRequestValue.PullRequests,
+				func(q *fieldValueNodesQuery) Connection[pullRequestNode] {
+					return q.Node.ProjectV2Item.FieldValueByName.ProjectV2ItemFieldPullRequestValue.PullRequests
+				})
+		case v.ProjectV2ItemFieldReviewerValue.Reviewers.PageInfo.HasNextPage:
+			err = fetchRemainingNodes(c, item.ID, fieldName, &v.ProjectV2ItemFieldReviewerValue.Reviewers,
+				func(q *fieldValueNodesQuery) Connection[reviewerNode] {
+					return q.Node.ProjectV2Item.FieldValueByName.ProjectV2ItemFieldReviewerValue.Reviewers
+				})
+		case v.ProjectV2ItemFieldUserValue.Users.PageInfo.HasNextPage:
+			err = fetchRemainingNodes(c, item.ID, fieldName, &v.ProjectV2ItemFieldUserValue.Users,
+				func(q *fieldValueNodesQuery) Connection[userNode] {
+					return q.Node.ProjectV2Item.FieldValueByName.ProjectV2ItemFieldUserValue.Users
+				})
+		}
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// fetchRemainingNodes appends the nodes beyond the first page to the connection of the field value of the item,
+// taken from the query by nodesOf.
+func fetchRemainingNodes[T any](
+	c *Client, itemID string, fieldName string, connection *Connection[T], nodesOf func(*fieldValueNodesQuery) Connection[T],
+) error {
+	cursor := graphql.String(connection.PageInfo.EndCursor)
+	nodes, err := fetchAllPages(maxPageSize, 0, func(first int, after *graphql.String) ([]T, PageInfo, error) {
+		if after == nil {
+			after = &cursor
+		}
 
-	err = client.Query("ProjectItems", &query, variables)
+		var query fieldValueNodesQuery
+		variables := map[string]interface{}{
+			gqlVarItemID:    graphql.ID(itemID),
+			gqlVarFieldName: graphql.String(fieldName),
+			gqlVarFirst:     graphql.Int(first), //nolint:gosec
+			gqlVarAfter:     after,
+		}
+
+		err := c.gql.Query("ProjectItemFieldValueNodes", &query, variables)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to fetch the values of %s of ProjectV2Item: %w", fieldName, err)
+		}
+		page := nodesOf(&query)
+		return page.Nodes, page.PageInfo, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch ProjectItems by project id: %w", err)
+		return err
 	}
-	return &query.Node.ProjectV2.Items.Nodes, nil
+
+	connection.Nodes = append(connection.Nodes, nodes...)
+	connection.PageInfo = PageInfo{HasNextPage: false, EndCursor: ""}
+	return nil
 }
@@ -2,9 +2,11 @@ package github_test
 
 import (
 	"os"
+	"slices"
+	"strconv"
 	"testing"
 
-	"github.com/tasshi-me/gh-iteration/pkg/github"
+	"github.com/tasshi-me/gh-iteration/pkg/github/githubtest"
 )
 
 func TestFetchProjectByNumber(t *testing.T) {
@@ -15,11 +17,12 @@ func TestFetchProjectByNumber(t *testing.T) {
 
 	login := testOrgLogin
 	projectNumber := 2
-	owner, err := github.FetchOwnerByLogin(login)
+	client := newClient(t)
+	owner, err := client.FetchOwnerByLogin(login)
 	if err != nil {
 		t.Fatal(err)
 	}
-	project, err := github.FetchProjectByNumber(projectNumber, owner.ID)
+	project, err := client.FetchProjectByNumber(projectNumber, owner.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,3 +36,199 @@ func TestFetchProjectByNumber(t *testing.T) {
 		t.Errorf("wrong owner type want: %d, got %d", projectNumber, project.Number)
 	}
 }
+
+func TestFetchProjectItems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pageSize  int
+		limit     int
+		wantItems []string
+		wantPages int
+	}{
+		{"single page", 100, 0, []string{"PVTI_1", "PVTI_2", "PVTI_3", "PVTI_4", "PVTI_5", "PVTI_6"}, 1},
+		{"multiple pages", 2, 0, []string{"PVTI_1", "PVTI_2", "PVTI_3", "PVTI_4", "PVTI_5", "PVTI_6"}, 3},
+		{"limit", 2, 3, []string{"PVTI_1", "PVTI_2", "PVTI_3"}, 2},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server := newFakeClient(t)
+			items, err := client.FetchProjectItems("PVT_roadmap", test.pageSize, test.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, 0, len(*items))
+			for _, item := range *items {
+				ids = append(ids, item.ID)
+			}
+			if !slices.Equal(ids, test.wantItems) {
+				t.Errorf("wrong items want: %v, got %v", test.wantItems, ids)
+			}
+			if pages := countOperations(server, "ProjectItems"); pages != test.wantPages {
+				t.Errorf("wrong number of pages want: %d, got %d", test.wantPages, pages)
+			}
+		})
+	}
+}
+
+func TestFetchProjectItem(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient(t)
+	item, err := client.FetchProjectItem("PVTI_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Content.Issue.Title != "Fix login bug" || item.Content.Issue.Repository.NameWithOwner != "acme/app" {
+		t.Errorf("wrong content: %+v", item.Content.Issue)
+	}
+	if item.Project.ID != "PVT_roadmap" {
+		t.Errorf("wrong project: %+v", item.Project)
+	}
+	if len(item.FieldValues.Nodes) != 10 {
+		t.Fatalf("wrong number of field values: %d", len(item.FieldValues.Nodes))
+	}
+	iteration := item.FieldValues.Nodes[1].ProjectV2ItemFieldIterationValue
+	if iteration.IterationID != "sprint_2" || iteration.Title != "Sprint 2" {
+		t.Errorf("wrong iteration value: %+v", iteration)
+	}
+	labels := item.FieldValues.Nodes[5]
+	if name := labels.Field().ProjectV2FieldCommon.Name; name != "Labels" {
+		t.Errorf("wrong field of label value: %s", name)
+	}
+	if nodes := labels.ProjectV2ItemFieldLabelValue.Labels.Nodes; len(nodes) != 2 || nodes[0].Name != "bug" {
+		t.Errorf("wrong label value: %+v", nodes)
+	}
+}
+
+func countOperations(server *githubtest.Server, name string) int {
+	count := 0
+	for _, op := range server.Operations() {
+		if op == name {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFetchProjectItemFieldValuesPagination(t *testing.T) {
+	t.Parallel()
+
+	const numFields = 250
+	project := githubtest.FixtureProject{
+		ID:     "PVT_wide",
+		Number: 1,
+		Title:  "Wide",
+		Fields: nil,
+		Items: []githubtest.FixtureItem{
+			{ID: "PVTI_wide", Type: "DRAFT_ISSUE", IsArchived: false, Content: map[string]any{"title": "Wide"}, FieldValues: map[string]any{}},
+		},
+	}
+	for i := range numFields {
+		name := "Field " + strconv.Itoa(i)
+		project.Fields = append(project.Fields, githubtest.FixtureField{
+			ID: "PVTF_" + strconv.Itoa(i), Name: name, DataType: "NUMBER", Options: nil, Configuration: nil,
+		})
+		project.Items[0].FieldValues[name] = float64(i)
+	}
+	fixture := &githubtest.Fixture{
+		Viewer: "",
+		Users:  nil,
+		Organizations: []githubtest.FixtureOwner{
+			{ID: "O_wide", Login: "wide", Name: "Wide", Projects: []githubtest.FixtureProject{project}},
+		},
+	}
+	server := githubtest.NewServer(fixture)
+	t.Cleanup(server.Close)
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := client.FetchProjectItems("PVT_wide", 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len((*items)[0].FieldValues.Nodes); got != numFields {
+		t.Errorf("wrong number of field values want: %d, got %d", numFields, got)
+	}
+
+	fields, err := client.FetchProjectFields("PVT_wide")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*fields) != numFields {
+		t.Errorf("wrong number of fields want: %d, got %d", numFields, len(*fields))
+	}
+}
+
+func TestFetchProjectItemFieldValueNodesPagination(t *testing.T) {
+	t.Parallel()
+
+	const numLabels = 250
+	const numAssignees = 15
+	labels := make([]any, 0, numLabels)
+	for i := range numLabels {
+		labels = append(labels, "label-"+strconv.Itoa(i))
+	}
+	assignees := make([]any, 0, numAssignees)
+	for i := range numAssignees {
+		assignees = append(assignees, "user-"+strconv.Itoa(i))
+	}
+	project := githubtest.FixtureProject{
+		ID:     "PVT_crowded",
+		Number: 1,
+		Title:  "Crowded",
+		Fields: []githubtest.FixtureField{
+			{ID: "PVTF_labels", Name: "Labels", DataType: "LABELS", Options: nil, Configuration: nil},
+			{ID: "PVTF_assignees", Name: "Assignees", DataType: "ASSIGNEES", Options: nil, Configuration: nil},
+		},
+		Items: []githubtest.FixtureItem{{
+			ID: "PVTI_crowded", Type: "DRAFT_ISSUE", IsArchived: false, Content: map[string]any{"title": "Crowded"},
+			FieldValues: map[string]any{"Labels": labels, "Assignees": assignees},
+		}},
+	}
+	fixture := &githubtest.Fixture{
+		Viewer: "",
+		Users:  nil,
+		Organizations: []githubtest.FixtureOwner{
+			{ID: "O_crowded", Login: "crowded", Name: "Crowded", Projects: []githubtest.FixtureProject{project}},
+		},
+	}
+	server := githubtest.NewServer(fixture)
+	t.Cleanup(server.Close)
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := client.FetchProjectItems("PVT_crowded", 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotLabels, gotAssignees []any
+	for _, v := range (*items)[0].FieldValues.Nodes {
+		for _, label := range v.ProjectV2ItemFieldLabelValue.Labels.Nodes {
+			gotLabels = append(gotLabels, label.Name)
+		}
+		for _, user := range v.ProjectV2ItemFieldUserValue.Users.Nodes {
+			gotAssignees = append(gotAssignees, user.Login)
+		}
+	}
+	if !slices.Equal(gotLabels, labels) {
+		t.Errorf("wrong labels want: %d labels, got %v", numLabels, gotLabels)
+	}
+	if !slices.Equal(gotAssignees, assignees) {
+		t.Errorf("wrong assignees want: %v, got %v", assignees, gotAssignees)
+	}
+	// The labels beyond the first 10 take 3 pages, and the assignees beyond the first 10 take 1 page.
+	if pages := countOperations(server, "ProjectItemFieldValueNodes"); pages != 4 {
+		t.Errorf("wrong number of pages want: 4, got %d", pages)
+	}
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tasshi-me/gh-iteration/pkg/log"
+)
+
+const (
+	// maxRateLimitRetries is the number of times a request hitting a rate limit is retried.
+	maxRateLimitRetries = 3
+	// defaultRateLimitWait is the wait before the first retry when GitHub doesn't tell how long to wait.
+	// https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+	defaultRateLimitWait = time.Minute
+	// maxRateLimitWait is the longest total wait for the rate limits of a request before giving up.
+	maxRateLimitWait = 15 * time.Minute
+)
+
+var errRateLimitWait = errors.New("rate limit wait too long")
+
+// rateLimitTransport retries the requests hitting the primary or secondary rate limits of GitHub
+// after the wait GitHub asks for.
+type rateLimitTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	sleep      func(time.Duration)
+	now        func() time.Time
+}
+
+func newRateLimitTransport(transport http.RoundTripper) *rateLimitTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		transport:  transport,
+		maxRetries: maxRateLimitRetries,
+		maxWait:    maxRateLimitWait,
+		sleep:      time.Sleep,
+		now:        time.Now,
+	}
+}
+
+// RoundTrip sends the request, and sends it again after waiting while it hits a rate limit,
+// until maxRetries retries or a total wait of maxWait.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		if attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		wait, reason, err := rateLimitWait(resp, attempt, t.now())
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		if len(reason) == 0 {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		if waited+wait > t.maxWait {
+			return nil, fmt.Errorf("%w: %s, GitHub asks to wait %s, more than %s in total", errRateLimitWait, reason, wait, t.maxWait)
+		}
+
+		log.Warn(fmt.Sprintf("Hit %s, retrying in %s", reason, wait))
+		t.sleep(wait)
+		waited += wait
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying the request answered with resp,
+// and the rate limit it hit, or an empty reason if the request didn't hit a rate limit.
+// It follows the Retry-After and X-RateLimit-Reset headers and backs off exponentially without them.
+// The primary rate limit of GraphQL is told by a RATE_LIMITED error in a 200 response.
+func rateLimitWait(resp *http.Response, attempt int, now time.Time) (time.Duration, string, error) {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		limited, err := hasRateLimitedError(resp)
+		if err != nil || !limited {
+			return 0, "", err
+		}
+		return primaryRateLimitWait(resp, attempt, now), "the GraphQL rate limit", nil
+	case http.StatusForbidden, http.StatusTooManyRequests:
+	default:
+		return 0, "", nil
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, "the secondary rate limit", nil
+	}
+	if resp.Header.Get("X-Ratelimit-Remaining") == "0" {
+		return primaryRateLimitWait(resp, attempt, now), "the primary rate limit", nil
+	}
+	backoff := defaultRateLimitWait << attempt
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return backoff, "the secondary rate limit", nil
+	}
+
+	// A secondary rate limit may be told only in the message of a 403 response.
+	body, err := readBody(resp)
+	if err != nil {
+		return 0, "", err
+	}
+	if strings.Contains(strings.ToLower(string(body)), "rate limit") {
+		return backoff, "the secondary rate limit", nil
+	}
+	return 0, "", nil
+}
+
+// primaryRateLimitWait returns the wait until the reset of the primary rate limit,
+// or an exponential backoff if the reset is not told.
+func primaryRateLimitWait(resp *http.Response, attempt int, now time.Time) time.Duration {
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		return max(time.Unix(reset, 0).Sub(now), 0)
+	}
+	return defaultRateLimitWait << attempt
+}
+
+// hasRateLimitedError reports whether the GraphQL response has an error of the RATE_LIMITED type.
+func hasRateLimitedError(resp *http.Response) (bool, error) {
+	body, err := readBody(resp)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Contains(body, []byte("RATE_LIMITED")) {
+		return false, nil
+	}
+	var response struct {
+		Errors []struct {
+			Type string `json:"type"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return false, nil //nolint:nilerr
+	}
+	for _, e := range response.Errors {
+		if e.Type == "RATE_LIMITED" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readBody reads the body of the response, and replaces it with a reader of the bytes read.
+func readBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,17 @@
+package github
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewRateLimitTransport creates a transport which waits for the rate limits with sleep.
+func NewRateLimitTransport(transport http.RoundTripper, sleep func(time.Duration), now time.Time) http.RoundTripper {
+	return &rateLimitTransport{
+		transport:  transport,
+		maxRetries: maxRateLimitRetries,
+		maxWait:    maxRateLimitWait,
+		sleep:      sleep,
+		now:        func() time.Time { return now },
+	}
+}
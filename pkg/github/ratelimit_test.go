@@ -0,0 +1,192 @@
+package github_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tasshi-me/gh-iteration/pkg/github"
+)
+
+// stubTransport answers the requests with the responses in order and records the request bodies.
+type stubTransport struct {
+	responses []*http.Response
+	bodies    []string
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	s.bodies = append(s.bodies, string(body))
+	resp := s.responses[0]
+	if len(s.responses) > 1 {
+		s.responses = s.responses[1:]
+	}
+	return resp, nil
+}
+
+func newResponse(status int, header http.Header, body string) *http.Response {
+	return &http.Response{ //nolint:exhaustruct
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_800_000_000, 0)
+	tests := []struct {
+		name         string
+		response     *http.Response
+		wantWaits    []time.Duration
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name:         "retry after",
+			response:     newResponse(http.StatusForbidden, http.Header{"Retry-After": {"30"}}, ""),
+			wantWaits:    []time.Duration{30 * time.Second, 30 * time.Second, 30 * time.Second},
+			wantRequests: 4,
+			wantErr:      false,
+		},
+		{
+			name: "primary rate limit",
+			response: newResponse(http.StatusForbidden, http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"1800000090"},
+			}, ""),
+			wantWaits:    []time.Duration{90 * time.Second, 90 * time.Second, 90 * time.Second},
+			wantRequests: 4,
+			wantErr:      false,
+		},
+		{
+			name:         "secondary rate limit",
+			response:     newResponse(http.StatusForbidden, http.Header{}, `{"message":"You have exceeded a secondary rate limit."}`),
+			wantWaits:    []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute},
+			wantRequests: 4,
+			wantErr:      false,
+		},
+		{
+			name: "GraphQL rate limit",
+			response: newResponse(http.StatusOK, http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"1800000060"},
+			}, `{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`),
+			wantWaits:    []time.Duration{time.Minute, time.Minute, time.Minute},
+			wantRequests: 4,
+			wantErr:      false,
+		},
+		{
+			name:         "GraphQL error",
+			response:     newResponse(http.StatusOK, http.Header{}, `{"data":null,"errors":[{"type":"NOT_FOUND","message":"Not found"}]}`),
+			wantWaits:    nil,
+			wantRequests: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "wait too long",
+			response:     newResponse(http.StatusForbidden, http.Header{"Retry-After": {"3600"}}, ""),
+			wantWaits:    nil,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "total wait too long",
+			response:     newResponse(http.StatusForbidden, http.Header{"Retry-After": {"400"}}, ""),
+			wantWaits:    []time.Duration{400 * time.Second, 400 * time.Second},
+			wantRequests: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "forbidden",
+			response:     newResponse(http.StatusForbidden, http.Header{}, `{"message":"Forbidden"}`),
+			wantWaits:    nil,
+			wantRequests: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "ok",
+			response:     newResponse(http.StatusOK, http.Header{}, `{"data":{}}`),
+			wantWaits:    nil,
+			wantRequests: 1,
+			wantErr:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			stub := &stubTransport{responses: []*http.Response{test.response}, bodies: nil}
+			var waits []time.Duration
+			transport := github.NewRateLimitTransport(stub, func(d time.Duration) { waits = append(waits, d) }, now)
+
+			req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader("query"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if test.wantErr {
+				if err == nil || resp != nil {
+					t.Errorf("want an error without a response, got %v and %v", resp, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				_ = resp.Body.Close()
+				if resp.StatusCode != test.response.StatusCode {
+					t.Errorf("want status %d, got %d", test.response.StatusCode, resp.StatusCode)
+				}
+			}
+			if len(stub.bodies) != test.wantRequests {
+				t.Errorf("want %d requests, got %d", test.wantRequests, len(stub.bodies))
+			}
+			for _, body := range stub.bodies {
+				if body != "query" {
+					t.Errorf("request body is not rewound: %q", body)
+				}
+			}
+			if len(waits) != len(test.wantWaits) {
+				t.Fatalf("want waits %v, got %v", test.wantWaits, waits)
+			}
+			for i := range waits {
+				if waits[i] != test.wantWaits[i] {
+					t.Errorf("want waits %v, got %v", test.wantWaits, waits)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportRecovers(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubTransport{
+		responses: []*http.Response{
+			newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, ""),
+			newResponse(http.StatusOK, http.Header{}, `{"data":{}}`),
+		},
+		bodies: nil,
+	}
+	transport := github.NewRateLimitTransport(stub, func(time.Duration) {}, time.Now())
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader("query"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || string(body) != `{"data":{}}` || len(stub.bodies) != 2 {
+		t.Errorf("not recovered: %d %s after %d requests", resp.StatusCode, body, len(stub.bodies))
+	}
+}